@@ -1,177 +1,1299 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"math/rand/v2"
-	"os"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/logan-waite/bootdev_pokedex/internal/pokeapi"
 )
 
-func initPokedex() {
-	initCommands()
-	initPokemonList()
-}
-
-// List of Caught Pokemon
-var pokemonList map[string]pokeapi.Pokemon
-
-func initPokemonList() {
-	pokemonList = map[string]pokeapi.Pokemon{}
-}
+// Resources kept in the offline name index
+var syncResources = []string{"pokemon", "location-area", "move", "item", "type"}
 
 // Command Registry
+// usage shows how a command is called and help is the longer text shown by
+// help <command>. aliases are other names the command answers to. flags
+// lists the flags a command takes besides globalFlags. A flag ending in =
+// takes a value, given as --name=value or --name value.
 type cliCommand struct {
 	name        string
+	group       string
+	usage       string
 	description string
-	callback    func(arg string) error
+	help        string
+	examples    []string
+	aliases     []string
+	flags       []string
+	callback    func(c *commandContext, args cliArgs) (cliResult, error)
 }
 
 var commands = map[string]cliCommand{}
 
+// Command names by alias
+var commandAliases = map[string]string{}
+
+// Command groups in the order help lists them
+var commandGroups = []string{"Exploring", "Your Pokedex", "Reference", "Settings", "Session"}
+
+// Flags every command takes
+var globalFlags = []string{"output=", "json"}
+
+// lookupCommand finds a command by its name or one of its aliases.
+func lookupCommand(name string) (cliCommand, bool) {
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+	command, ok := commands[name]
+	return command, ok
+}
+
+// flagSpec reports whether a command takes a flag and whether the flag takes
+// a value.
+func (command cliCommand) flagSpec(name string) (known bool, takesValue bool) {
+	for _, flag := range append(slices.Clone(globalFlags), command.flags...) {
+		if flag == name {
+			return true, false
+		}
+		if flag == name+"=" {
+			return true, true
+		}
+	}
+	return false, false
+}
+
 func initCommands() {
 	commands = map[string]cliCommand{
 		"exit": {
 			name:        "exit",
+			group:       "Session",
+			usage:       "exit",
 			description: "Exit the Pokedex",
+			help:        "Saves your command history and ends the session. Ctrl-D at an empty prompt does the same.",
+			aliases:     []string{"quit"},
 			callback:    commandExit,
 		},
 		"help": {
 			name:        "help",
-			description: "Displays a help message",
+			group:       "Session",
+			usage:       "help [command]",
+			description: "Show the commands, or the details of one",
+			help:        "With no command, lists every command by group. With a command name or alias, shows how to call it, what it does and some examples.",
+			examples:    []string{"help", "help search"},
 			callback:    commandHelp,
 		},
 		"map": {
 			name:        "map",
-			description: "List the next 20 locations in the database",
+			group:       "Exploring",
+			usage:       "map [region]",
+			description: "List the next 20 location areas",
+			help:        "Pages forward through the location areas, 20 at a time. With a region, pages through the areas of that region's locations instead, 20 locations at a time, and mapb keeps to the same region.",
+			examples:    []string{"map", "map sinnoh"},
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "list the previous 20 locations in the database",
+			group:       "Exploring",
+			usage:       "mapb [region]",
+			description: "List the previous 20 location areas",
+			help:        "Pages back through the location areas listed by map. With a region, pages back through the areas of that region's locations, 20 locations at a time.",
+			examples:    []string{"mapb", "mapb kanto"},
 			callback:    commandMapb,
 		},
 		"explore": {
 			name:        "explore",
-			description: "explore a location (explore <location name>)",
+			group:       "Exploring",
+			usage:       "explore <location area>",
+			description: "List the pokemon found in a location area",
+			help:        "Lists the pokemon that can be encountered in a location area, with the method, levels, chance and conditions of each encounter. With an active game, only that game's encounters are shown.",
+			examples:    []string{"explore sinnoh-route-201-area", "explore eterna-city-area --output table"},
 			callback:    commandExplore,
 		},
 		"catch": {
 			name:        "catch",
-			description: "attempt to catch a pokemon (catch <pokemon name>)",
+			group:       "Exploring",
+			usage:       "catch <pokemon>",
+			description: "Try to catch a pokemon",
+			help:        "Throws a Pokeball at a pokemon. The more base experience a pokemon has, the harder it is to catch. Whether it is caught depends on the session's seed, so the same seed and commands give the same catches.",
+			examples:    []string{"catch starly", "catch pikachu"},
 			callback:    commandCatch,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "view the characteristics of a pokemon you have caught (inspect <pokemon name>)",
+			group:       "Your Pokedex",
+			usage:       "inspect <pokemon>",
+			description: "Show the details of a pokemon you have caught",
+			help:        "Shows a caught pokemon's genus, description, height, weight, base stats, types and abilities. With an active game, also lists the moves it learns in that game.",
+			examples:    []string{"inspect starly", "inspect starly --output yaml"},
 			callback:    commandInspect,
 		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "view a list of all collected pokemon",
+			group:       "Your Pokedex",
+			usage:       "pokedex",
+			description: "List the pokemon you have caught",
+			help:        "Lists every pokemon caught this session with its types and base stats.",
+			examples:    []string{"pokedex", "pokedex --output csv"},
+			aliases:     []string{"dex"},
 			callback:    commandPokedex,
 		},
+		"item": {
+			name:        "item",
+			group:       "Reference",
+			usage:       "item <item>",
+			description: "Show the details of an item",
+			help:        "Shows an item's cost, category, pocket, fling power and effect, and the pokemon that can be found holding it. Berries also show their firmness, growth time and natural gift.",
+			examples:    []string{"item master-ball", "item oran-berry"},
+			callback:    commandItem,
+		},
+		"region": {
+			name:        "region",
+			group:       "Exploring",
+			usage:       "region <region>",
+			description: "List the locations and areas of a region",
+			help:        "Lists every location in a region with the areas in it, ready to explore.",
+			examples:    []string{"region sinnoh"},
+			callback:    commandRegion,
+		},
+		"version": {
+			name:        "version",
+			group:       "Settings",
+			usage:       "version [version|all]",
+			description: "Show or set the active game",
+			help:        "Sets the game whose data explore, where and inspect show, such as platinum. all shows every game again. With no argument, shows the active game.",
+			examples:    []string{"version platinum", "version all"},
+			callback:    commandVersion,
+		},
+		"version-group": {
+			name:        "version-group",
+			group:       "Settings",
+			usage:       "version-group [version group|all]",
+			description: "Show or set the active version group",
+			help:        "Like version, but makes every game in a version group active, such as diamond and pearl for diamond-pearl.",
+			examples:    []string{"version-group diamond-pearl", "version-group all"},
+			aliases:     []string{"vg"},
+			callback:    commandVersionGroup,
+		},
+		"nature": {
+			name:        "nature",
+			group:       "Reference",
+			usage:       "nature <nature>",
+			description: "Show the stats a nature raises and lowers",
+			help:        "Shows the stat a nature raises and the one it lowers, and the berry flavors it likes and hates.",
+			examples:    []string{"nature adamant"},
+			callback:    commandNature,
+		},
+		"where": {
+			name:        "where",
+			group:       "Exploring",
+			usage:       "where <pokemon>",
+			description: "List the location areas where a pokemon can be found",
+			help:        "Lists the location areas where a pokemon appears in the wild, by game, with the methods, levels and chance. With an active game, only that game is listed.",
+			examples:    []string{"where starly", "where pikachu --output csv"},
+			callback:    commandWhere,
+		},
+		"lang": {
+			name:        "lang",
+			group:       "Settings",
+			usage:       "lang [language code]",
+			description: "Show or set the display language",
+			help:        "Sets the language of names, genera and descriptions, such as fr or ja-Hrkt. The session starts in POKEDEX_LANG, or English. With no argument, shows the display language.",
+			examples:    []string{"lang fr", "lang en"},
+			aliases:     []string{"language"},
+			callback:    commandLang,
+		},
+		"sync": {
+			name:        "sync",
+			group:       "Reference",
+			usage:       "sync [pokemon|location-area|move|item|type]",
+			description: "Download the name index for offline search",
+			help:        "Downloads the names of one kind of resource, or of every kind, for search and tab completion. A sync that was interrupted picks up where it stopped.",
+			examples:    []string{"sync", "sync move"},
+			callback:    commandSync,
+		},
+		"search": {
+			name:        "search",
+			group:       "Reference",
+			usage:       "search <part of a name> [--resource=<resource>]",
+			description: "Search the synced names",
+			help:        "Lists the synced names that contain the search text. --resource searches one kind of resource only. Run sync first.",
+			examples:    []string{"search pika", "search punch --resource=move"},
+			aliases:     []string{"find"},
+			flags:       []string{"resource="},
+			callback:    commandSearch,
+		},
+		"source": {
+			name:        "source",
+			group:       "Session",
+			usage:       "source <file>",
+			description: "Run the commands in a script file",
+			help:        "Runs the commands in a file, one per line, as if they were typed at the prompt. Lines starting with # are comments. After set -e, the script stops at the first command that fails.",
+			examples:    []string{"source catches.txt"},
+			callback:    commandSource,
+		},
+		"output": {
+			name:        "output",
+			group:       "Settings",
+			usage:       "output [text|json|yaml|csv|table]",
+			description: "Show or set the output format",
+			help:        "Sets how results are shown for the rest of the session. --output=<format>, or --json, changes the format of a single command.",
+			examples:    []string{"output json", "pokedex --output table"},
+			callback:    commandOutput,
+		},
+		"seed": {
+			name:        "seed",
+			group:       "Settings",
+			usage:       "seed [number]",
+			description: "Show or set the seed for catches",
+			help:        "Restarts the random source for catches from a seed. A session started with the same seed, or --seed, that runs the same commands gets the same catches. With no argument, shows the seed so the session can be replayed.",
+			examples:    []string{"seed", "seed 42"},
+			callback:    commandSeed,
+		},
+	}
+
+	commandAliases = map[string]string{}
+	for name, command := range commands {
+		for _, alias := range command.aliases {
+			commandAliases[alias] = name
+		}
 	}
 }
 
 // Command Callbacks
-func commandExit(_ string) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+// errExit is returned by the exit command to end the session.
+var errExit = errors.New("exit")
+
+func commandExit(c *commandContext, args cliArgs) (cliResult, error) {
+	fmt.Fprintln(c.out, "Closing the Pokedex... Goodbye!")
+	return cliResult{}, errExit
 }
 
-func commandHelp(_ string) error {
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Print("Usage:\n\n")
+type helpEntry struct {
+	Name        string   `json:"name"`
+	Group       string   `json:"group"`
+	Usage       string   `json:"usage"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+type helpPage struct {
+	Name        string   `json:"name"`
+	Usage       string   `json:"usage"`
+	Description string   `json:"description"`
+	Help        string   `json:"help"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Examples    []string `json:"examples,omitempty"`
+}
 
+func commandHelp(c *commandContext, args cliArgs) (cliResult, error) {
+	if commandArg := strings.ToLower(args.name()); commandArg != "" {
+		return commandHelpPage(commandArg)
+	}
+
+	entries := []helpEntry{}
 	for _, command := range commands {
-		fmt.Printf("%s: %s\n", command.name, command.description)
+		entries = append(entries, helpEntry{command.name, command.group, command.usage, command.description, command.aliases})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		gi, gj := slices.Index(commandGroups, entries[i].Group), slices.Index(commandGroups, entries[j].Group)
+		if gi != gj {
+			return gi < gj
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return cliResult{data: entries, text: func(w io.Writer) {
+		width := 0
+		for _, entry := range entries {
+			width = max(width, len(entry.Usage))
+		}
+		fmt.Fprintln(w, "Welcome to the Pokedex!")
+		group := ""
+		for _, entry := range entries {
+			if entry.Group != group {
+				group = entry.Group
+				fmt.Fprintf(w, "\n%s:\n", group)
+			}
+			fmt.Fprintf(w, "  %-*s  %s\n", width, entry.Usage, entry.Description)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Every command also takes --output=<format> and --json.")
+		fmt.Fprintln(w, `Run "help <command>" for more about a command.`)
+	}}, nil
+}
+
+func commandHelpPage(name string) (cliResult, error) {
+	command, ok := lookupCommand(name)
+	if !ok {
+		names := []string{}
+		for name := range commands {
+			names = append(names, name)
+		}
+		if suggestions := pokeapi.Suggest(name, names); len(suggestions) > 0 {
+			return cliResult{}, fmt.Errorf("no %v command; did you mean %v?", name, strings.Join(suggestions, ", "))
+		}
+		return cliResult{}, fmt.Errorf("no %v command", name)
+	}
+
+	page := helpPage{command.name, command.usage, command.description, command.help, command.aliases, command.examples}
+	return cliResult{data: page, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Usage: %s\n", page.Usage)
+		if len(page.Aliases) > 0 {
+			fmt.Fprintf(w, "Aliases: %s\n", strings.Join(page.Aliases, ", "))
+		}
+		fmt.Fprintln(w)
+		for _, line := range wrapText(page.Help, 72) {
+			fmt.Fprintln(w, line)
+		}
+		if len(page.Examples) > 0 {
+			fmt.Fprintln(w, "\nExamples:")
+			for _, example := range page.Examples {
+				fmt.Fprintf(w, "  %s\n", example)
+			}
+		}
+	}}, nil
+}
+
+func commandMap(c *commandContext, args cliArgs) (cliResult, error) {
+	regionArg := args.name()
+	if regionArg != "" {
+		return commandRegionMap(c, regionArg, "next")
+	}
+	result, err := c.client.GetLocationAreas(c.ctx, "next")
+	if err != nil {
+		return cliResult{}, err
+	}
+	c.mapRegion = ""
+	return listAreas(c, result), nil
+}
+
+func commandMapb(c *commandContext, args cliArgs) (cliResult, error) {
+	regionArg := args.name()
+	if regionArg == "" {
+		regionArg = c.mapRegion
+	}
+	if regionArg != "" {
+		return commandRegionMap(c, regionArg, "prev")
+	}
+	result, err := c.client.GetLocationAreas(c.ctx, "prev")
+	if err != nil {
+		return cliResult{}, err
+	}
+	return listAreas(c, result), nil
+}
+
+// commandRegionMap pages through a region's areas, and keeps mapb to that
+// region only once the region is found.
+func commandRegionMap(c *commandContext, region string, paginate string) (cliResult, error) {
+	result, err := c.client.GetRegionLocationAreas(c.ctx, region, paginate)
+	if err != nil {
+		return cliResult{}, err
+	}
+	c.mapRegion = region
+	return listAreas(c, result), nil
+}
+
+// listEntry is a name in a list, with its localized name when the display
+// language has a different one.
+type listEntry struct {
+	Name          string `json:"name"`
+	LocalizedName string `json:"localized_name,omitempty"`
+}
+
+func (e listEntry) String() string {
+	return withLocalizedName(e.Name, e.LocalizedName)
+}
+
+func listAreas(c *commandContext, areas []pokeapi.NamedApiResource) cliResult {
+	entries := []listEntry{}
+	localizedNames := c.localizedNames(areas, (*commandContext).localizedAreaName)
+	for i, area := range areas {
+		entries = append(entries, listEntry{area.Name, localizedNames[i]})
+		c.listedAreas[area.Name] = true
+	}
+
+	return cliResult{data: entries, text: func(w io.Writer) {
+		for _, entry := range entries {
+			fmt.Fprintln(w, entry)
+		}
+	}}
+}
+
+// localizedNames looks up the display-language names of a page of location
+// areas or pokemon with localize, several at a time. With the default
+// language there is nothing to look up.
+func (c *commandContext) localizedNames(resources []pokeapi.NamedApiResource, localize func(*commandContext, string) string) []string {
+	if c.language == pokeapi.DefaultLanguage {
+		return make([]string, len(resources))
+	}
+	names, err := pokeapi.FetchEach(c.ctx, resources, func(ctx context.Context, resource pokeapi.NamedApiResource) (string, error) {
+		return localize(c, resource.Name), nil
+	})
+	if err != nil {
+		return make([]string, len(resources))
+	}
+	return names
+}
+
+// localizedAreaName returns a location area's name in the display language,
+// or "" when that is no different from its plain name, which is what explore
+// takes.
+func (c *commandContext) localizedAreaName(area string) string {
+	if c.language == pokeapi.DefaultLanguage {
+		return ""
 	}
-	fmt.Print("\n")
-	return nil
+	location, err := c.client.GetLocationAreaData(c.ctx, area)
+	if err != nil {
+		return ""
+	}
+	return localizedOnly(area, pokeapi.LocalizedName(location.Names, c.language, area))
 }
 
-func commandMap(_ string) error {
-	result, err := pokeapi.GetLocationAreas("next")
+// localizedPokemonName returns a pokemon's species name in the display
+// language, or "" when that is no different from its plain name. The species
+// comes from the pokemon, since forms such as wormadam-plant have no species
+// of their own name.
+func (c *commandContext) localizedPokemonName(pokemon string) string {
+	if c.language == pokeapi.DefaultLanguage {
+		return ""
+	}
+	summary, err := c.client.GetPokemonSummary(c.ctx, pokemon)
 	if err != nil {
-		return err
+		return ""
+	}
+	species, err := pokeapi.Resolve[pokeapi.PokemonSpecies](c.ctx, c.client, summary.Species)
+	if err != nil {
+		return ""
+	}
+	return localizedOnly(pokemon, pokeapi.LocalizedName(species.Names, c.language, pokemon))
+}
+
+func localizedOnly(name string, localized string) string {
+	if strings.EqualFold(localized, name) {
+		return ""
 	}
-	for _, location := range result {
-		fmt.Println(location.Name)
+	return localized
+}
+
+func withLocalizedName(name string, localized string) string {
+	if localized == "" || strings.EqualFold(localized, name) {
+		return name
 	}
-	return nil
+	return fmt.Sprintf("%s (%s)", name, localized)
 }
 
-func commandMapb(_ string) error {
-	result, err := pokeapi.GetLocationAreas("prev")
+type regionLocation struct {
+	Name  string   `json:"name"`
+	Areas []string `json:"areas"`
+}
+
+func commandRegion(c *commandContext, args cliArgs) (cliResult, error) {
+	regionArg := args.name()
+	if regionArg == "" {
+		return cliResult{}, fmt.Errorf("need a region to list")
+	}
+	region, err := c.client.GetRegion(c.ctx, regionArg)
+	if err != nil {
+		return cliResult{}, err
+	}
+	fetched, err := c.client.GetLocations(c.ctx, region.Locations)
 	if err != nil {
-		return err
+		return cliResult{}, err
 	}
-	for _, location := range result {
-		fmt.Println(location.Name)
+	locations := []regionLocation{}
+	for _, location := range fetched {
+		entry := regionLocation{Name: location.Name, Areas: []string{}}
+		for _, area := range location.Areas {
+			entry.Areas = append(entry.Areas, area.Name)
+			c.listedAreas[area.Name] = true
+		}
+		locations = append(locations, entry)
 	}
-	return nil
+
+	return cliResult{data: locations, text: func(w io.Writer) {
+		for _, location := range locations {
+			fmt.Fprintln(w, location.Name)
+			for _, area := range location.Areas {
+				fmt.Fprintf(w, "- %v\n", area)
+			}
+		}
+	}}, nil
+}
+
+type exploreResult struct {
+	Area          string            `json:"area"`
+	LocalizedName string            `json:"localized_name,omitempty"`
+	Pokemon       []exploredPokemon `json:"pokemon"`
 }
 
-func commandExplore(locationArg string) error {
+type exploredPokemon struct {
+	Name          string   `json:"name"`
+	LocalizedName string   `json:"localized_name,omitempty"`
+	Encounters    []string `json:"encounters"`
+}
+
+func commandExplore(c *commandContext, args cliArgs) (cliResult, error) {
+	locationArg := args.name()
 	if locationArg == "" {
-		return fmt.Errorf("need a location to explore")
+		return cliResult{}, fmt.Errorf("need a location to explore")
 	}
-	location, err := pokeapi.GetLocationAreaData(locationArg)
+	location, err := c.client.GetLocationAreaData(c.ctx, locationArg)
 	if err != nil {
-		return err
+		return cliResult{}, err
+	}
+	c.exploredArea = location
+
+	result := exploreResult{Area: location.Name, Pokemon: []exploredPokemon{}}
+	if c.language != pokeapi.DefaultLanguage {
+		result.LocalizedName = pokeapi.LocalizedName(location.Names, c.language, location.Name)
 	}
+	found := []pokeapi.NamedApiResource{}
 	for _, pokemon := range location.PokemonEncounters {
-		fmt.Println(pokemon.Pokemon.Name)
+		encounters := []string{}
+		for _, details := range pokemon.VersionDetails {
+			if !c.game.includesVersion(details.Version.Name) {
+				continue
+			}
+			for _, detail := range details.EncounterDetails {
+				encounter := describeEncounter(detail)
+				if !slices.Contains(encounters, encounter) {
+					encounters = append(encounters, encounter)
+				}
+			}
+		}
+		if len(encounters) == 0 {
+			continue
+		}
+		found = append(found, pokemon.Pokemon)
+		result.Pokemon = append(result.Pokemon, exploredPokemon{Name: pokemon.Pokemon.Name, Encounters: encounters})
+	}
+	for i, name := range c.localizedNames(found, (*commandContext).localizedPokemonName) {
+		result.Pokemon[i].LocalizedName = name
+	}
+
+	return cliResult{data: result, text: func(w io.Writer) {
+		if result.LocalizedName != "" {
+			fmt.Fprintf(w, "Exploring %v...\n", result.LocalizedName)
+		}
+		for _, pokemon := range result.Pokemon {
+			fmt.Fprintln(w, withLocalizedName(pokemon.Name, pokemon.LocalizedName))
+			for _, encounter := range pokemon.Encounters {
+				fmt.Fprintf(w, "- %v\n", encounter)
+			}
+		}
+	}}, nil
+}
+
+// describeEncounter summarizes one way of encountering a pokemon, such as
+// "walk, lv 2-3, 10% (time-morning)".
+func describeEncounter(detail pokeapi.Encounter) string {
+	levels := fmt.Sprintf("lv %v", detail.MinLevel)
+	if detail.MaxLevel != detail.MinLevel {
+		levels = fmt.Sprintf("lv %v-%v", detail.MinLevel, detail.MaxLevel)
+	}
+	description := fmt.Sprintf("%v, %v, %v%%", detail.Method.Name, levels, detail.Chance)
+	if len(detail.ConditionValues) > 0 {
+		conditions := []string{}
+		for _, condition := range detail.ConditionValues {
+			conditions = append(conditions, condition.Name)
+		}
+		description += fmt.Sprintf(" (%v)", strings.Join(conditions, ", "))
 	}
-	return nil
+	return description
 }
 
-func commandCatch(pokemonArg string) error {
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonArg)
+type catchResult struct {
+	Pokemon string `json:"pokemon"`
+	Caught  bool   `json:"caught"`
+}
+
+func commandCatch(c *commandContext, args cliArgs) (cliResult, error) {
+	pokemonArg := args.name()
 	if pokemonArg == "" {
-		return fmt.Errorf("need a pokemon to catch!")
+		return cliResult{}, fmt.Errorf("need a pokemon to catch!")
 	}
-	pokemon, err := pokeapi.GetPokemon(pokemonArg)
+	summary, err := c.client.GetPokemonSummary(c.ctx, pokemonArg)
 	if err != nil {
-		return err
+		return cliResult{}, err
 	}
-	target := pokemon.BaseExperience - (25 + (pokemon.BaseExperience / 10))
-	attempt := rand.IntN(pokemon.BaseExperience)
-	if attempt >= target {
-		fmt.Printf("%s was caught!\n", pokemon.Name)
-		pokemonList[pokemon.Name] = pokemon
-	} else {
-		fmt.Printf("%s escaped!\n", pokemon.Name)
+	result := catchResult{Pokemon: summary.Name}
+	// Some forms have no base experience; they are always caught.
+	caught := summary.BaseExperience <= 0
+	if !caught {
+		target := summary.BaseExperience - (25 + (summary.BaseExperience / 10))
+		caught = c.rng.IntN(summary.BaseExperience) >= target
 	}
-	return nil
+	if caught {
+		// The summary is all an escape needs. A caught pokemon is then
+		// decoded in full: over REST from the response the summary came
+		// from, over GraphQL with a second, larger query.
+		pokemon, err := c.client.GetPokemon(c.ctx, summary.Name)
+		if err != nil {
+			return cliResult{}, err
+		}
+		c.pokemonList[pokemon.Name] = pokemon
+		result.Caught = true
+	}
+
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Throwing a Pokeball at %s...\n", result.Pokemon)
+		if result.Caught {
+			fmt.Fprintf(w, "%s was caught!\n", result.Pokemon)
+		} else {
+			fmt.Fprintf(w, "%s escaped!\n", result.Pokemon)
+		}
+	}}, nil
+}
+
+type inspectResult struct {
+	Name          string         `json:"name"`
+	LocalizedName string         `json:"localized_name,omitempty"`
+	Genus         string         `json:"genus,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Height        int            `json:"height"`
+	Weight        int            `json:"weight"`
+	Stats         []statEntry    `json:"stats"`
+	Types         []string       `json:"types"`
+	Abilities     []abilityEntry `json:"abilities"`
+	VersionGroup  string         `json:"version_group,omitempty"`
+	Moves         []moveEntry    `json:"moves,omitempty"`
+}
+
+type statEntry struct {
+	Name string `json:"name"`
+	Base int    `json:"base"`
+}
+
+type abilityEntry struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
 }
 
-func commandInspect(pokemonArg string) error {
-	pokemon, ok := pokemonList[pokemonArg]
+type moveEntry struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Level  int    `json:"level,omitempty"`
+}
+
+func commandInspect(c *commandContext, args cliArgs) (cliResult, error) {
+	pokemonArg := args.name()
+	pokemon, ok := c.findCaughtPokemon(pokemonArg)
 	if !ok {
-		return fmt.Errorf("you have not caught a %v yet", pokemonArg)
+		names := []string{}
+		for name := range c.pokemonList {
+			names = append(names, name)
+		}
+		if suggestions := pokeapi.Suggest(pokeapi.Slug(pokemonArg), names); len(suggestions) > 0 {
+			return cliResult{}, fmt.Errorf("you have not caught a %v yet; did you mean %v?", pokemonArg, strings.Join(suggestions, ", "))
+		}
+		return cliResult{}, fmt.Errorf("you have not caught a %v yet", pokemonArg)
+	}
+	// Without the species, the pokemon is shown without its localized name,
+	// genus and description rather than not at all.
+	species, err := pokeapi.Resolve[pokeapi.PokemonSpecies](c.ctx, c.client, pokemon.Species)
+	if err != nil && c.ctx.Err() != nil {
+		return cliResult{}, c.ctx.Err()
 	}
 
-	fmt.Printf("Name: %v\n", pokemon.Name)
-	fmt.Printf("Height: %v\n", pokemon.Height)
-	fmt.Printf("Weight: %v\n", pokemon.Weight)
-	fmt.Println("Stats:")
+	result := inspectResult{
+		Name:         pokemon.Name,
+		Genus:        pokeapi.LocalizedGenus(species.Genera, c.language),
+		Description:  pokeapi.LocalizedFlavorText(species.FlavorTextEntries, c.language, c.game.version.Name),
+		Height:       pokemon.Height,
+		Weight:       pokemon.Weight,
+		Stats:        []statEntry{},
+		Types:        []string{},
+		Abilities:    []abilityEntry{},
+		VersionGroup: c.game.versionGroup.Name,
+	}
+	if c.language != pokeapi.DefaultLanguage {
+		result.LocalizedName = localizedOnly(pokemon.Name, pokeapi.LocalizedName(species.Names, c.language, pokemon.Name))
+	}
 	for _, stat := range pokemon.Stats {
-		fmt.Printf("- %v: %v\n", stat.Stat.Name, stat.BaseStat)
+		result.Stats = append(result.Stats, statEntry{stat.Stat.Name, stat.BaseStat})
+	}
+	for _, pokemonType := range c.game.pokemonTypes(pokemon) {
+		result.Types = append(result.Types, pokemonType.Type.Name)
+	}
+	for _, ability := range c.game.pokemonAbilities(pokemon) {
+		result.Abilities = append(result.Abilities, abilityEntry{ability.Ability.Name, ability.IsHidden})
+	}
+	if c.game.versionGroup.Name != "" {
+		for _, move := range pokemon.Moves {
+			for _, details := range move.VersionGroupDetails {
+				if details.VersionGroup.Name != c.game.versionGroup.Name {
+					continue
+				}
+				entry := moveEntry{Name: move.Move.Name, Method: details.MoveLearnMethod.Name}
+				if entry.Method == "level-up" {
+					entry.Level = details.LevelLearnedAt
+				}
+				result.Moves = append(result.Moves, entry)
+			}
+		}
+	}
+
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Name: %v\n", withLocalizedName(result.Name, result.LocalizedName))
+		if result.Genus != "" {
+			fmt.Fprintf(w, "Genus: %v\n", result.Genus)
+		}
+		if result.Description != "" {
+			fmt.Fprintf(w, "Description: %v\n", result.Description)
+		}
+		fmt.Fprintf(w, "Height: %v\n", result.Height)
+		fmt.Fprintf(w, "Weight: %v\n", result.Weight)
+		fmt.Fprintln(w, "Stats:")
+		for _, stat := range result.Stats {
+			fmt.Fprintf(w, "- %v: %v\n", stat.Name, stat.Base)
+		}
+		fmt.Fprintln(w, "Types:")
+		for _, pokemonType := range result.Types {
+			fmt.Fprintf(w, "- %v\n", pokemonType)
+		}
+		fmt.Fprintln(w, "Abilities:")
+		for _, ability := range result.Abilities {
+			if ability.Hidden {
+				fmt.Fprintf(w, "- %v (hidden)\n", ability.Name)
+			} else {
+				fmt.Fprintf(w, "- %v\n", ability.Name)
+			}
+		}
+		if result.VersionGroup != "" {
+			fmt.Fprintf(w, "Moves (%v):\n", result.VersionGroup)
+			for _, move := range result.Moves {
+				if move.Method == "level-up" {
+					fmt.Fprintf(w, "- %v (level-up, lv %v)\n", move.Name, move.Level)
+				} else {
+					fmt.Fprintf(w, "- %v (%v)\n", move.Name, move.Method)
+				}
+			}
+		}
+	}}, nil
+}
+
+// findCaughtPokemon looks up a caught pokemon by name or pokedex id.
+func (s *session) findCaughtPokemon(nameOrID string) (pokeapi.Pokemon, bool) {
+	slug := pokeapi.Slug(nameOrID)
+	if pokemon, ok := s.pokemonList[slug]; ok {
+		return pokemon, true
+	}
+	for _, pokemon := range s.pokemonList {
+		if strconv.Itoa(pokemon.ID) == slug {
+			return pokemon, true
+		}
+	}
+	return pokeapi.Pokemon{}, false
+}
+
+// pokemonTypes returns the pokemon's types as of the active game's
+// generation. Each past types entry holds the types last used in its
+// generation, so the earliest entry at or after the active generation wins.
+func (g activeGame) pokemonTypes(pokemon pokeapi.Pokemon) []pokeapi.PokemonType {
+	generation := g.versionGroup.Generation.ID()
+	if generation == 0 {
+		return pokemon.Types
+	}
+	types := pokemon.Types
+	latest := 0
+	for _, past := range pokemon.PastTypes {
+		pastGeneration := past.Generation.ID()
+		if pastGeneration >= generation && (latest == 0 || pastGeneration < latest) {
+			types = past.Types
+			latest = pastGeneration
+		}
+	}
+	return types
+}
+
+// pokemonAbilities returns the pokemon's abilities as of the active game's
+// generation. Past ability entries only list the slots that changed, so they
+// are applied from the newest generation back to the active one. A slot with
+// no ability did not exist yet.
+func (g activeGame) pokemonAbilities(pokemon pokeapi.Pokemon) []pokeapi.PokemonAbility {
+	generation := g.versionGroup.Generation.ID()
+	if generation == 0 {
+		return pokemon.Abilities
+	}
+
+	past := []pokeapi.PokemonAbilityPast{}
+	for _, entry := range pokemon.PastAbilities {
+		if entry.Generation.ID() >= generation {
+			past = append(past, entry)
+		}
+	}
+	sort.Slice(past, func(i, j int) bool {
+		return past[i].Generation.ID() > past[j].Generation.ID()
+	})
+
+	slots := map[int]pokeapi.PokemonAbility{}
+	for _, ability := range pokemon.Abilities {
+		slots[ability.Slot] = ability
+	}
+	for _, entry := range past {
+		for _, ability := range entry.Abilities {
+			if ability.Ability.Name == "" {
+				delete(slots, ability.Slot)
+			} else {
+				slots[ability.Slot] = ability
+			}
+		}
+	}
+
+	abilities := []pokeapi.PokemonAbility{}
+	for _, ability := range slots {
+		abilities = append(abilities, ability)
+	}
+	sort.Slice(abilities, func(i, j int) bool {
+		return abilities[i].Slot < abilities[j].Slot
+	})
+	return abilities
+}
+
+// pokedexEntry is a row of the pokedex table.
+type pokedexEntry struct {
+	Name           string   `json:"name"`
+	Types          []string `json:"types"`
+	HP             int      `json:"hp"`
+	Attack         int      `json:"attack"`
+	Defense        int      `json:"defense"`
+	SpecialAttack  int      `json:"special_attack"`
+	SpecialDefense int      `json:"special_defense"`
+	Speed          int      `json:"speed"`
+}
+
+func commandPokedex(c *commandContext, args cliArgs) (cliResult, error) {
+	entries := []pokedexEntry{}
+	for _, pokemon := range c.pokemonList {
+		entry := pokedexEntry{Name: pokemon.Name, Types: []string{}}
+		for _, pokemonType := range c.game.pokemonTypes(pokemon) {
+			entry.Types = append(entry.Types, pokemonType.Type.Name)
+		}
+		stats := map[string]*int{
+			"hp":              &entry.HP,
+			"attack":          &entry.Attack,
+			"defense":         &entry.Defense,
+			"special-attack":  &entry.SpecialAttack,
+			"special-defense": &entry.SpecialDefense,
+			"speed":           &entry.Speed,
+		}
+		for _, stat := range pokemon.Stats {
+			if value, ok := stats[stat.Stat.Name]; ok {
+				*value = stat.BaseStat
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return cliResult{data: entries}, nil
+}
+
+type itemResult struct {
+	Name       string       `json:"name"`
+	Cost       int          `json:"cost"`
+	Category   string       `json:"category"`
+	Pocket     string       `json:"pocket"`
+	FlingPower int          `json:"fling_power"`
+	Effect     string       `json:"effect,omitempty"`
+	Berry      *berryResult `json:"berry,omitempty"`
+	HeldBy     []string     `json:"held_by,omitempty"`
+}
+
+type berryResult struct {
+	Firmness         string `json:"firmness"`
+	GrowthTime       int    `json:"growth_time"`
+	NaturalGiftType  string `json:"natural_gift_type"`
+	NaturalGiftPower int    `json:"natural_gift_power"`
+}
+
+func commandItem(c *commandContext, args cliArgs) (cliResult, error) {
+	itemArg := args.name()
+	if itemArg == "" {
+		return cliResult{}, fmt.Errorf("need an item to look up")
+	}
+	item, err := c.client.GetItem(c.ctx, itemArg)
+	if err != nil {
+		return cliResult{}, err
+	}
+	category, err := pokeapi.Resolve[pokeapi.ItemCategory](c.ctx, c.client, item.Category)
+	if err != nil {
+		return cliResult{}, err
+	}
+
+	result := itemResult{
+		Name:       item.Name,
+		Cost:       item.Cost,
+		Category:   category.Name,
+		Pocket:     category.Pocket.Name,
+		FlingPower: item.FlingPower,
+	}
+	for _, entry := range item.EffectEntries {
+		if entry.Language.Name == "en" {
+			result.Effect = strings.Join(strings.Fields(entry.ShortEffect), " ")
+			break
+		}
+	}
+
+	if category.Pocket.Name == "berries" {
+		berry, err := c.client.GetBerry(c.ctx, strings.TrimSuffix(item.Name, "-berry"))
+		if err != nil {
+			return cliResult{}, err
+		}
+		result.Berry = &berryResult{
+			Firmness:         berry.Firmness.Name,
+			GrowthTime:       berry.GrowthTime,
+			NaturalGiftType:  berry.NaturalGiftType.Name,
+			NaturalGiftPower: berry.NaturalGiftPower,
+		}
+	}
+
+	for name, pokemon := range c.pokemonList {
+		for _, held := range pokemon.HeldItems {
+			if held.Item.Name == item.Name {
+				result.HeldBy = append(result.HeldBy, name)
+				break
+			}
+		}
+	}
+
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Name: %v\n", result.Name)
+		fmt.Fprintf(w, "Cost: %v\n", result.Cost)
+		fmt.Fprintf(w, "Category: %v (%v pocket)\n", result.Category, result.Pocket)
+		if result.FlingPower > 0 {
+			fmt.Fprintf(w, "Fling Power: %v\n", result.FlingPower)
+		} else {
+			fmt.Fprintln(w, "Fling Power: -")
+		}
+		if result.Effect != "" {
+			fmt.Fprintf(w, "Effect: %v\n", result.Effect)
+		}
+		if berry := result.Berry; berry != nil {
+			fmt.Fprintln(w, "Berry:")
+			fmt.Fprintf(w, "- firmness: %v\n", berry.Firmness)
+			fmt.Fprintf(w, "- growth time: %v\n", berry.GrowthTime)
+			fmt.Fprintf(w, "- natural gift: %v (%v)\n", berry.NaturalGiftType, berry.NaturalGiftPower)
+		}
+		if len(result.HeldBy) > 0 {
+			fmt.Fprintln(w, "Held by caught pokemon:")
+			for _, name := range result.HeldBy {
+				fmt.Fprintf(w, "- %v\n", name)
+			}
+		}
+	}}, nil
+}
+
+type gameResult struct {
+	Version      string `json:"version,omitempty"`
+	VersionGroup string `json:"version_group,omitempty"`
+}
+
+// activeGameResult reports the active game after version or version-group
+// changes it.
+func activeGameResult(g activeGame) cliResult {
+	active := g
+	return cliResult{
+		data: gameResult{active.version.Name, active.versionGroup.Name},
+		text: func(w io.Writer) {
+			fmt.Fprintf(w, "Active game: %v\n", active)
+		},
+	}
+}
+
+func commandVersion(c *commandContext, args cliArgs) (cliResult, error) {
+	versionArg := args.name()
+	if pokeapi.Slug(versionArg) == "all" {
+		c.game = activeGame{}
+	} else if versionArg != "" {
+		version, err := c.client.GetVersion(c.ctx, versionArg)
+		if err != nil {
+			return cliResult{}, err
+		}
+		versionGroup, err := pokeapi.Resolve[pokeapi.VersionGroup](c.ctx, c.client, version.VersionGroup)
+		if err != nil {
+			return cliResult{}, err
+		}
+		c.game = activeGame{version: version, versionGroup: versionGroup}
 	}
-	fmt.Println("Types:")
-	for _, pokemonType := range pokemon.Types {
-		fmt.Printf("- %v\n", pokemonType.Type.Name)
+	return activeGameResult(c.game), nil
+}
+
+func commandVersionGroup(c *commandContext, args cliArgs) (cliResult, error) {
+	versionGroupArg := args.name()
+	if pokeapi.Slug(versionGroupArg) == "all" {
+		c.game = activeGame{}
+	} else if versionGroupArg != "" {
+		versionGroup, err := c.client.GetVersionGroup(c.ctx, versionGroupArg)
+		if err != nil {
+			return cliResult{}, err
+		}
+		c.game = activeGame{versionGroup: versionGroup}
+	}
+	return activeGameResult(c.game), nil
+}
+
+type natureResult struct {
+	Name   string `json:"name"`
+	Raises string `json:"raises,omitempty"`
+	Lowers string `json:"lowers,omitempty"`
+	Likes  string `json:"likes,omitempty"`
+	Hates  string `json:"hates,omitempty"`
+}
+
+func commandNature(c *commandContext, args cliArgs) (cliResult, error) {
+	natureArg := args.name()
+	if natureArg == "" {
+		return cliResult{}, fmt.Errorf("need a nature to look up")
+	}
+	nature, err := c.client.GetNature(c.ctx, natureArg)
+	if err != nil {
+		return cliResult{}, err
+	}
+
+	result := natureResult{
+		Name:   nature.Name,
+		Raises: nature.IncreasedStat.Name,
+		Lowers: nature.DecreasedStat.Name,
+		Likes:  nature.LikesFlavor.Name,
+		Hates:  nature.HatesFlavor.Name,
+	}
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Name: %v\n", result.Name)
+		if result.Raises == "" {
+			fmt.Fprintln(w, "Neutral: no stats raised or lowered")
+			return
+		}
+		fmt.Fprintf(w, "Raises: %v\n", result.Raises)
+		fmt.Fprintf(w, "Lowers: %v\n", result.Lowers)
+		fmt.Fprintf(w, "Likes: %v flavors\n", result.Likes)
+		fmt.Fprintf(w, "Hates: %v flavors\n", result.Hates)
+	}}, nil
+}
+
+type whereEntry struct {
+	Version  string   `json:"version"`
+	Area     string   `json:"area"`
+	Methods  []string `json:"methods"`
+	MinLevel int      `json:"min_level"`
+	MaxLevel int      `json:"max_level"`
+	Chance   int      `json:"chance"`
+}
+
+func commandWhere(c *commandContext, args cliArgs) (cliResult, error) {
+	pokemonArg := args.name()
+	if pokemonArg == "" {
+		return cliResult{}, fmt.Errorf("need a pokemon to find")
+	}
+	encounters, err := c.client.GetPokemonEncounters(c.ctx, pokemonArg)
+	if err != nil {
+		return cliResult{}, err
+	}
+
+	byVersion := map[string][]whereEntry{}
+	versions := []pokeapi.NamedApiResource{}
+	for _, encounter := range encounters {
+		for _, details := range encounter.VersionDetails {
+			if !c.game.includesVersion(details.Version.Name) {
+				continue
+			}
+			entry := whereEntry{
+				Version: details.Version.Name,
+				Area:    encounter.LocationArea.Name,
+				Methods: []string{},
+				Chance:  details.MaxChance,
+			}
+			for _, detail := range details.EncounterDetails {
+				if !slices.Contains(entry.Methods, detail.Method.Name) {
+					entry.Methods = append(entry.Methods, detail.Method.Name)
+				}
+				if entry.MinLevel == 0 || detail.MinLevel < entry.MinLevel {
+					entry.MinLevel = detail.MinLevel
+				}
+				if detail.MaxLevel > entry.MaxLevel {
+					entry.MaxLevel = detail.MaxLevel
+				}
+			}
+			if _, ok := byVersion[details.Version.Name]; !ok {
+				versions = append(versions, details.Version)
+			}
+			byVersion[details.Version.Name] = append(byVersion[details.Version.Name], entry)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ID() < versions[j].ID()
+	})
+	entries := []whereEntry{}
+	for _, version := range versions {
+		entries = append(entries, byVersion[version.Name]...)
+	}
+
+	active := c.game
+	return cliResult{data: entries, text: func(w io.Writer) {
+		if len(entries) == 0 {
+			fmt.Fprintf(w, "%s cannot be found in the wild in %v\n", pokemonArg, active)
+			return
+		}
+		for _, version := range versions {
+			fmt.Fprintf(w, "%v:\n", version.Name)
+			for _, entry := range byVersion[version.Name] {
+				fmt.Fprintf(w, "- %v: %v, lv %v-%v, %v%%\n", entry.Area, strings.Join(entry.Methods, "/"), entry.MinLevel, entry.MaxLevel, entry.Chance)
+			}
+		}
+	}}, nil
+}
+
+type languageResult struct {
+	Language string `json:"language"`
+}
+
+func commandLang(c *commandContext, args cliArgs) (cliResult, error) {
+	languageArg := args.name()
+	if languageArg != "" {
+		lang, err := c.client.GetLanguage(c.ctx, languageArg)
+		if err != nil {
+			return cliResult{}, err
+		}
+		c.language = lang.Name
+	}
+	result := languageResult{c.language}
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Display language: %v\n", result.Language)
+	}}, nil
+}
+
+type syncEntry struct {
+	Resource string `json:"resource"`
+	Names    int    `json:"names"`
+}
+
+// commandSync reports progress on the error writer as it goes, so its result is only
+// needed by the structured formats.
+func commandSync(c *commandContext, args cliArgs) (cliResult, error) {
+	resourceArg := pokeapi.Slug(args.name())
+	resources := syncResources
+	if resourceArg != "" {
+		if !slices.Contains(syncResources, resourceArg) {
+			return cliResult{}, fmt.Errorf("cannot sync %v; choose one of %v", resourceArg, strings.Join(syncResources, ", "))
+		}
+		resources = []string{resourceArg}
+	}
+
+	entries := []syncEntry{}
+	for _, resource := range resources {
+		err := c.client.SyncIndex(c.ctx, resource, func(done int, total int) {
+			fmt.Fprintf(c.errOut, "\rSyncing %v... %v/%v", resource, done, total)
+		})
+		fmt.Fprintln(c.errOut)
+		if err != nil {
+			return cliResult{}, fmt.Errorf("sync of %v stopped, run sync again to resume: %w", resource, err)
+		}
+		index, _ := c.client.CachedIndex(resource)
+		entries = append(entries, syncEntry{resource, len(index)})
+	}
+	return cliResult{data: entries, text: func(w io.Writer) {}}, nil
+}
+
+type searchMatch struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	ID       int    `json:"id"`
+}
+
+func commandSearch(c *commandContext, args cliArgs) (cliResult, error) {
+	queryArg := args.name()
+	query := pokeapi.Slug(queryArg)
+	if query == "" {
+		return cliResult{}, fmt.Errorf("need part of a name to search for")
+	}
+
+	resources := syncResources
+	if resource, ok := args.flag("resource"); ok {
+		resource = pokeapi.Slug(resource)
+		if !slices.Contains(syncResources, resource) {
+			return cliResult{}, fmt.Errorf("cannot search %v; choose one of %v", resource, strings.Join(syncResources, ", "))
+		}
+		resources = []string{resource}
+	}
+
+	searched := false
+	matches := []searchMatch{}
+	for _, resource := range resources {
+		index, ok := c.client.CachedIndex(resource)
+		if !ok {
+			continue
+		}
+		searched = true
+		for _, entry := range index {
+			if strings.Contains(entry.Name, query) {
+				matches = append(matches, searchMatch{resource, entry.Name, entry.ID()})
+			}
+		}
+	}
+	if !searched {
+		return cliResult{}, fmt.Errorf("nothing to search yet; run `sync` first")
+	}
+
+	return cliResult{data: matches, text: func(w io.Writer) {
+		resource := ""
+		for _, match := range matches {
+			if match.Resource != resource {
+				resource = match.Resource
+				fmt.Fprintf(w, "%v:\n", resource)
+			}
+			fmt.Fprintf(w, "- %v (#%v)\n", match.Name, match.ID)
+		}
+	}}, nil
+}
+
+func commandSource(c *commandContext, args cliArgs) (cliResult, error) {
+	fileArg := args.name()
+	if fileArg == "" {
+		return cliResult{}, fmt.Errorf("need a script file to run")
 	}
+	return cliResult{}, runScript(c, fileArg)
+}
+
+type outputResult struct {
+	Format string `json:"format"`
+}
+
+func commandOutput(c *commandContext, args cliArgs) (cliResult, error) {
+	formatArg := strings.ToLower(args.name())
+	if formatArg != "" {
+		if err := checkFormat(formatArg); err != nil {
+			return cliResult{}, err
+		}
+		c.outputFormat = formatArg
+	}
+	result := outputResult{c.outputFormat}
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Output format: %v\n", result.Format)
+	}}, nil
+}
 
-	return nil
+type seedResult struct {
+	Seed uint64 `json:"seed"`
 }
 
-func commandPokedex(_ string) error {
-	for key := range pokemonList {
-		fmt.Printf("- %v\n", key)
+func commandSeed(c *commandContext, args cliArgs) (cliResult, error) {
+	seedArg := args.name()
+	if seedArg != "" {
+		seed, err := strconv.ParseUint(seedArg, 10, 64)
+		if err != nil {
+			return cliResult{}, fmt.Errorf("seed must be a whole number, not %v", seedArg)
+		}
+		c.setSeed(seed)
 	}
-	return nil
+	result := seedResult{c.seed}
+	return cliResult{data: result, text: func(w io.Writer) {
+		fmt.Fprintf(w, "Seed: %v\n", result.Seed)
+	}}, nil
 }
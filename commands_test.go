@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/logan-waite/bootdev_pokedex/internal/pokeapi"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// newFixtureServer serves the PokeAPI responses kept in testdata/api, so
+// /api/v2/pokemon/starly is testdata/api/pokemon/starly.json.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2"), "/")
+		http.ServeFile(w, r, filepath.Join("testdata", "api", filepath.FromSlash(path)+".json"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// checkGolden compares a command's output with testdata/golden/name.golden,
+// or rewrites the file when the tests run with -update.
+func checkGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("%v: output differs from %v, got:\n%s", name, path, actual)
+	}
+}
+
+// runInput runs one line of input as the REPL would.
+func runInput(t *testing.T, c *commandContext, input string) {
+	t.Helper()
+	cmd, args, err := parseInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	command, ok := lookupCommand(cmd)
+	if !ok {
+		t.Fatalf("%v: unknown command", input)
+	}
+	if err := checkFlags(command, args); err != nil {
+		t.Fatalf("%v: %v", input, err)
+	}
+	if err := runCommand(c, command, args); err != nil {
+		t.Fatalf("%v: unexpected error: %v", input, err)
+	}
+}
+
+func TestCommandOutput(t *testing.T) {
+	server := newFixtureServer(t)
+	diamondPearl := activeGame{versionGroup: pokeapi.VersionGroup{
+		Name:     "diamond-pearl",
+		Versions: []pokeapi.NamedApiResource{{Name: "diamond"}, {Name: "pearl"}},
+	}}
+	diamond := activeGame{version: pokeapi.Version{Name: "diamond"}, versionGroup: diamondPearl.versionGroup}
+
+	cases := []struct {
+		golden   string
+		input    string
+		language string
+		game     activeGame
+	}{
+		{golden: "inspect", input: "inspect starly"},
+		{golden: "inspect-game", input: "inspect starly", game: diamondPearl},
+		{golden: "inspect-lang", input: "inspect starly", language: "fr"},
+		{golden: "inspect-json", input: "inspect starly --json"},
+		{golden: "inspect-yaml", input: "inspect starly --output yaml", game: diamondPearl},
+		{golden: "inspect-csv", input: "inspect starly --output csv", game: diamondPearl},
+		{golden: "pokedex", input: "pokedex"},
+		{golden: "pokedex", input: "dex"},
+		{golden: "pokedex-json", input: "pokedex --json"},
+		{golden: "pokedex-csv", input: "pokedex --output csv"},
+		{golden: "explore", input: "explore sinnoh-route-201-area"},
+		{golden: "explore-game", input: "explore sinnoh-route-201-area", game: diamond},
+		{golden: "explore-lang", input: "explore sinnoh-route-201-area", language: "fr"},
+		{golden: "explore-table", input: "explore sinnoh-route-201-area --output table"},
+		{golden: "where", input: "where starly"},
+		{golden: "where-game", input: "where starly", game: diamondPearl},
+		{golden: "where-csv", input: "where starly --output csv"},
+		{golden: "item", input: "item master-ball"},
+		{golden: "item-berry", input: `item "Oran Berry"`},
+		{golden: "item-berry-json", input: "item oran-berry --json"},
+		{golden: "region", input: "region kanto"},
+		{golden: "nature", input: "nature Adamant"},
+		{golden: "nature-neutral", input: "nature hardy"},
+		{golden: "nature-json", input: "nature adamant --json"},
+		{golden: "version", input: "version Platinum"},
+		{golden: "version-all", input: "version all", game: diamond},
+		{golden: "version-group", input: "vg diamond-pearl"},
+		{golden: "lang", input: "lang fr"},
+		{golden: "lang-mixed-case", input: "lang ja-Hrkt"},
+		{golden: "help", input: "help"},
+		{golden: "help-search", input: "help find"},
+		{golden: "help-search-json", input: "help search --json"},
+	}
+	for _, tc := range cases {
+		c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+		starly, err := c.client.GetPokemon(c.ctx, "starly")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.pokemonList[starly.Name] = starly
+		if tc.language != "" {
+			c.language = tc.language
+		}
+		c.game = tc.game
+
+		runInput(t, c, tc.input)
+		checkGolden(t, tc.golden, out.Bytes())
+	}
+}
+
+func TestRegionMap(t *testing.T) {
+	server := newFixtureServer(t)
+	c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	fails := func(input string, expected string) {
+		t.Helper()
+		command, args, _ := parseInput(input)
+		err := runCommand(c, commands[command], args)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%v: expected an error containing %q, got %v", input, expected, err)
+		}
+	}
+
+	runInput(t, c, "map kanto")
+	fails("mapb", "No previous map")
+	runInput(t, c, "map kanto")
+	fails("map kanto", "No more locations")
+	// A region that is not found leaves mapb on the last one.
+	fails("map kantoo", `no region named "kantoo"`)
+	runInput(t, c, "mapb")
+	fails("mapb", "No previous map")
+	checkGolden(t, "map-region", out.Bytes())
+}
+
+func TestSyncAndSearch(t *testing.T) {
+	server := newFixtureServer(t)
+	c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+
+	_, args, _ := parseInput("search star")
+	if _, err := commandSearch(c, args); err == nil || !strings.Contains(err.Error(), "run `sync` first") {
+		t.Errorf("expected searching before sync to fail, got %v", err)
+	}
+	runInput(t, c, "sync")
+	runInput(t, c, "search star")
+	runInput(t, c, "search Star --resource item")
+	runInput(t, c, "sync pokemon --json")
+	checkGolden(t, "sync-search", out.Bytes())
+}
+
+func TestInspectWithoutSpecies(t *testing.T) {
+	server := newFixtureServer(t)
+	c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	starly, err := c.client.GetPokemon(c.ctx, "starly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	starly.Species.Url = "/api/v2/pokemon-species/missing/"
+	c.pokemonList[starly.Name] = starly
+
+	runInput(t, c, "inspect starly")
+	checkGolden(t, "inspect-no-species", out.Bytes())
+}
+
+func TestCatchWithoutBaseExperience(t *testing.T) {
+	server := newFixtureServer(t)
+	c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	runInput(t, c, "catch eternatus-eternamax")
+	if _, ok := c.pokemonList["eternatus-eternamax"]; !ok {
+		t.Errorf("expected a pokemon with no base experience to be caught, got %q", out.String())
+	}
+}
+
+func TestHelpUnknownCommand(t *testing.T) {
+	c, _ := newTestContext(t)
+	_, args, _ := parseInput("help serch")
+	if _, err := commandHelp(c, args); err == nil || !strings.Contains(err.Error(), "did you mean search?") {
+		t.Errorf("expected a suggestion, got %v", err)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	server := newFixtureServer(t)
+	catches := func(seed string) string {
+		c, out := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+		runInput(t, c, "seed "+seed)
+		for range 20 {
+			runInput(t, c, "catch starly")
+		}
+		return out.String()
+	}
+
+	first := catches("42")
+	if !strings.Contains(first, "caught") || !strings.Contains(first, "escaped") {
+		t.Fatalf("expected both catches and escapes, got:\n%v", first)
+	}
+	if second := catches("42"); second != first {
+		t.Errorf("expected the same catches with the same seed, got:\n%v\nthen:\n%v", first, second)
+	}
+	if other := catches("43"); other == first {
+		t.Errorf("expected different catches with another seed")
+	}
+
+	c, out := newTestContext(t)
+	runInput(t, c, "seed 7")
+	runInput(t, c, "seed")
+	if out.String() != "Seed: 7\nSeed: 7\n" {
+		t.Errorf("expected seed 7, got %q", out.String())
+	}
+	for _, input := range []string{"seed -1", "seed lots"} {
+		_, args, _ := parseInput(input)
+		if _, err := commandSeed(c, args); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestCancelCommand(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c, _ := newTestContext(t, pokeapi.WithBaseURL(server.URL+"/api/v2"))
+	ctx, cancel := context.WithCancel(context.Background())
+	c.ctx = ctx
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, args, _ := parseInput("explore slow-area")
+		done <- runCommand(c, commands["explore"], args)
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the command to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("explore did not stop when its context was cancelled")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+)
+
+// complete returns the tab completion candidates for the word after
+// head: command names for the first word, then whatever the command takes.
+// Candidates come from what the session already knows and the synced name
+// index, never from a fresh request.
+func (s *session) complete(head string) []string {
+	words := strings.Fields(strings.ToLower(head))
+	if len(words) == 0 {
+		names := []string{}
+		for name := range commands {
+			names = append(names, name)
+		}
+		return names
+	}
+	if len(words) > 1 {
+		return nil
+	}
+
+	names := []string{}
+	command, _ := lookupCommand(words[0])
+	switch command.name {
+	case "explore":
+		for name := range s.listedAreas {
+			names = append(names, name)
+		}
+		if index, ok := s.client.CachedIndex("location-area"); ok {
+			for _, area := range index {
+				names = append(names, area.Name)
+			}
+		}
+	case "catch":
+		for _, encounter := range s.exploredArea.PokemonEncounters {
+			names = append(names, encounter.Pokemon.Name)
+		}
+	case "inspect":
+		for name := range s.pokemonList {
+			names = append(names, name)
+		}
+	case "sync":
+		names = append(names, syncResources...)
+	case "output":
+		names = append(names, formatNames()...)
+	case "help":
+		for name := range commands {
+			names = append(names, name)
+		}
+	}
+	return names
+}
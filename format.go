@@ -0,0 +1,355 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// cliResult is what a command produces. data is a struct, or a slice of
+// structs, whose json tags name its fields; the structured formats are
+// built from it. text is the command's own layout for the text format, when
+// the generic one will not do.
+type cliResult struct {
+	data any
+	text func(w io.Writer)
+}
+
+// Output Formats
+type formatter func(w io.Writer, r cliResult) error
+
+var formatters = map[string]formatter{
+	"text":  formatText,
+	"json":  formatJSON,
+	"yaml":  formatYAML,
+	"csv":   formatCSV,
+	"table": formatTable,
+}
+
+func formatNames() []string {
+	names := []string{}
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func checkFormat(format string) error {
+	if _, ok := formatters[format]; !ok {
+		return fmt.Errorf("no %v output format; choose one of %v", format, strings.Join(formatNames(), ", "))
+	}
+	return nil
+}
+
+// resultFormat picks the format for one command's result: --output, then
+// --json, then the session's format.
+func resultFormat(sessionFormat string, args cliArgs) (string, error) {
+	if format, ok := args.flag("output"); ok {
+		format = strings.ToLower(format)
+		return format, checkFormat(format)
+	}
+	if args.enabled("json") {
+		return "json", nil
+	}
+	return sessionFormat, nil
+}
+
+// render writes a command's result in format. A result with no data only
+// shows up in the text format.
+func render(w io.Writer, format string, r cliResult) error {
+	if format == "text" {
+		if r.data == nil && r.text == nil {
+			return nil
+		}
+		return formatText(w, r)
+	}
+	if r.data == nil {
+		return nil
+	}
+	return formatters[format](w, r)
+}
+
+// formatText uses the command's own layout, or else shows lists as a table
+// and single results as YAML.
+func formatText(w io.Writer, r cliResult) error {
+	if r.text != nil {
+		r.text(w)
+		return nil
+	}
+	if reflect.ValueOf(r.data).Kind() == reflect.Slice {
+		return formatTable(w, r)
+	}
+	return formatYAML(w, r)
+}
+
+func formatJSON(w io.Writer, r cliResult) error {
+	data, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func formatYAML(w io.Writer, r cliResult) error {
+	writeYAML(w, reflect.ValueOf(r.data), "")
+	return nil
+}
+
+func formatCSV(w io.Writer, r cliResult) error {
+	columns, rows := tabulate(r.data)
+	out := csv.NewWriter(w)
+	out.Write(columns)
+	out.WriteAll(rows)
+	return out.Error()
+}
+
+func formatTable(w io.Writer, r cliResult) error {
+	columns, rows := tabulate(r.data)
+	if len(rows) == 0 {
+		return nil
+	}
+	out := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := []string{}
+	for _, column := range columns {
+		headers = append(headers, strings.ToUpper(column))
+	}
+	fmt.Fprintln(out, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(out, strings.Join(row, "\t"))
+	}
+	return out.Flush()
+}
+
+/*** Reflection ***/
+// field is one json-tagged field of a result struct.
+type field struct {
+	name  string
+	value reflect.Value
+}
+
+// fields lists a struct's json-tagged fields in order, leaving out empty
+// omitempty fields.
+func fields(v reflect.Value) []field {
+	result := []field{}
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if options == "omitempty" && v.Field(i).IsZero() {
+			continue
+		}
+		result = append(result, field{name, v.Field(i)})
+	}
+	return result
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+func isScalar(v reflect.Value) bool {
+	switch indirect(v).Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		return false
+	}
+	return true
+}
+
+// writeYAML writes v as indented YAML-like text: fields as "name: value",
+// list items after "- ".
+func writeYAML(w io.Writer, v reflect.Value, indent string) {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fields(v) {
+			writeYAMLField(w, f.name, f.value, indent)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			item := indirect(v.Index(i))
+			if isScalar(item) {
+				fmt.Fprintf(w, "%s- %s\n", indent, yamlScalar(item))
+				continue
+			}
+			// The first line of an item goes after its dash.
+			var b strings.Builder
+			writeYAML(&b, item, indent+"  ")
+			fmt.Fprintf(w, "%s- %s", indent, strings.TrimPrefix(b.String(), indent+"  "))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			writeYAMLField(w, key.String(), v.MapIndex(key), indent)
+		}
+	default:
+		fmt.Fprintf(w, "%s%s\n", indent, yamlScalar(v))
+	}
+}
+
+func writeYAMLField(w io.Writer, name string, v reflect.Value, indent string) {
+	v = indirect(v)
+	switch {
+	case isScalar(v):
+		fmt.Fprintf(w, "%s%s: %s\n", indent, name, yamlScalar(v))
+	case v.Kind() == reflect.Slice && v.Len() == 0:
+		fmt.Fprintf(w, "%s%s: []\n", indent, name)
+	default:
+		fmt.Fprintf(w, "%s%s:\n", indent, name)
+		writeYAML(w, v, indent+"  ")
+	}
+}
+
+// yamlScalar quotes strings that would not read back as plain text.
+func yamlScalar(v reflect.Value) string {
+	if !v.IsValid() {
+		return "null"
+	}
+	if v.Kind() != reflect.String {
+		return fmt.Sprint(v.Interface())
+	}
+	s := v.String()
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, ":#\n\"'[]{},&*!|>%@`") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// tabulate turns a result into rows for CSV and tables. The columns are the
+// rows' fields in the order they first appear, and nested values are
+// flattened into one cell.
+func tabulate(data any) ([]string, [][]string) {
+	v := indirect(reflect.ValueOf(data))
+	records := [][]field{}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			records = append(records, record(v.Index(i)))
+		}
+	case reflect.Struct:
+		records = expand(v)
+	default:
+		records = append(records, record(v))
+	}
+
+	columns := []string{}
+	for _, fields := range records {
+		for _, f := range fields {
+			if !slices.Contains(columns, f.name) {
+				columns = append(columns, f.name)
+			}
+		}
+	}
+	rows := [][]string{}
+	for _, fields := range records {
+		cells := map[string]string{}
+		for _, f := range fields {
+			cells[f.name] = flatten(f.value)
+		}
+		row := []string{}
+		for _, column := range columns {
+			row = append(row, cells[column])
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+func record(v reflect.Value) []field {
+	v = indirect(v)
+	if v.Kind() == reflect.Struct {
+		return fields(v)
+	}
+	return []field{{"value", v}}
+}
+
+// expand makes one row of a struct, or one row per item when the struct
+// holds a single list of structs, as explore's result holds its pokemon.
+func expand(v reflect.Value) [][]field {
+	all := fields(v)
+	nested := -1
+	for i, f := range all {
+		list := indirect(f.value)
+		if list.Kind() != reflect.Slice {
+			continue
+		}
+		item := list.Type().Elem()
+		for item.Kind() == reflect.Pointer {
+			item = item.Elem()
+		}
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+		if nested >= 0 {
+			return [][]field{all}
+		}
+		nested = i
+	}
+	if nested < 0 {
+		return [][]field{all}
+	}
+
+	parent := slices.Delete(slices.Clone(all), nested, nested+1)
+	list := indirect(all[nested].value)
+	rows := [][]field{}
+	for i := 0; i < list.Len(); i++ {
+		row := slices.Clone(parent)
+		for _, f := range record(list.Index(i)) {
+			// An item's field named like one of the struct's is prefixed
+			// with the list's name, as in moves_name.
+			if slices.ContainsFunc(parent, func(p field) bool { return p.name == f.name }) {
+				f.name = all[nested].name + "_" + f.name
+			}
+			row = append(row, f)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// flatten writes a value on one line: lists joined with "; " and structs as
+// "name=value" pairs.
+func flatten(v reflect.Value) string {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Slice:
+		parts := []string{}
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, flatten(v.Index(i)))
+		}
+		return strings.Join(parts, "; ")
+	case reflect.Struct:
+		parts := []string{}
+		for _, f := range fields(v) {
+			parts = append(parts, f.name+"="+flatten(f.value))
+		}
+		return strings.Join(parts, " ")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		parts := []string{}
+		for _, key := range keys {
+			parts = append(parts, key.String()+"="+flatten(v.MapIndex(key)))
+		}
+		return strings.Join(parts, " ")
+	}
+	return fmt.Sprint(v.Interface())
+}
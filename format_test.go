@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testMove struct {
+	Name  string `json:"name"`
+	Level int    `json:"level,omitempty"`
+}
+
+type testPokemon struct {
+	Name  string     `json:"name"`
+	Types []string   `json:"types"`
+	Moves []testMove `json:"moves"`
+}
+
+func TestFormatters(t *testing.T) {
+	pokemon := testPokemon{
+		Name:  "bulbasaur",
+		Types: []string{"grass", "poison"},
+		Moves: []testMove{{"tackle", 1}, {"vine-whip", 3}, {"cut", 0}},
+	}
+	list := []testPokemon{pokemon, {Name: "pikachu", Types: []string{"electric"}, Moves: []testMove{}}}
+
+	cases := []struct {
+		format   string
+		data     any
+		expected string
+	}{
+		{
+			format: "json",
+			data:   testMove{"tackle", 1},
+			expected: `{
+  "name": "tackle",
+  "level": 1
+}
+`,
+		},
+		{
+			format: "yaml",
+			data:   pokemon,
+			expected: `name: bulbasaur
+types:
+  - grass
+  - poison
+moves:
+  - name: tackle
+    level: 1
+  - name: vine-whip
+    level: 3
+  - name: cut
+`,
+		},
+		{
+			format: "yaml",
+			data:   []testMove{{"tackle", 1}},
+			expected: `- name: tackle
+  level: 1
+`,
+		},
+		{
+			format: "csv",
+			data:   list,
+			expected: `name,types,moves
+bulbasaur,grass; poison,name=tackle level=1; name=vine-whip level=3; name=cut
+pikachu,electric,
+`,
+		},
+		{
+			format: "csv",
+			data:   pokemon,
+			expected: `name,types,moves_name,level
+bulbasaur,grass; poison,tackle,1
+bulbasaur,grass; poison,vine-whip,3
+bulbasaur,grass; poison,cut,
+`,
+		},
+		{
+			format: "table",
+			data:   []testMove{{"tackle", 1}, {"vine-whip", 3}},
+			expected: `NAME       LEVEL
+tackle     1
+vine-whip  3
+`,
+		},
+		{
+			format: "text",
+			data:   []testMove{{"cut", 0}},
+			expected: `NAME
+cut
+`,
+		},
+	}
+
+	for _, c := range cases {
+		var out bytes.Buffer
+		if err := render(&out, c.format, cliResult{data: c.data}); err != nil {
+			t.Errorf("%v: unexpected error: %v", c.format, err)
+			continue
+		}
+		if out.String() != c.expected {
+			t.Errorf("%v: expected\n%s\ngot\n%s", c.format, c.expected, out.String())
+		}
+	}
+}
@@ -2,6 +2,4 @@ module github.com/logan-waite/bootdev_pokedex
 
 go 1.23.3
 
-replace github.com/logan-waite/bootdev_pokedex/internal/pokeapi v0.0.0 => ./internal/pokeapi
-
-require github.com/logan-waite/bootdev_pokedex/internal/pokeapi v0.0.0
+require golang.org/x/sys v0.33.0
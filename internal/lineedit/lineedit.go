@@ -0,0 +1,510 @@
+// Package lineedit reads REPL input with cursor movement, history recall and
+// reverse search when attached to a terminal, and plain lines otherwise.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MaxHistory is how many lines of history are kept.
+const MaxHistory = 1000
+
+// maxListed caps how many candidates a double Tab lists.
+const maxListed = 60
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C.
+var ErrInterrupted = errors.New("interrupted")
+
+/*** Editor ***/
+// Types
+
+// Completer returns the candidates for the word under the cursor, given the
+// text of the line before that word. The editor keeps the candidates that
+// start with what has been typed of the word.
+type Completer func(head string) []string
+
+// Editor reads lines from in, echoing and redrawing them on out.
+type Editor struct {
+	History  []string
+	Complete Completer
+
+	in          *os.File
+	out         io.Writer
+	reader      *bufio.Reader
+	scanner     *bufio.Scanner
+	terminal    bool
+	historyFile string
+
+	mu      sync.Mutex
+	restore func()
+}
+
+// New returns an editor reading from in. History is loaded from historyFile,
+// and each entered line is appended to it; an empty historyFile keeps
+// history in memory only. When in or out is not a terminal the editor reads
+// plain lines.
+func New(in *os.File, out io.Writer, historyFile string) *Editor {
+	e := &Editor{in: in, out: out, historyFile: historyFile}
+	e.terminal = isTerminal(int(in.Fd()))
+	if f, ok := out.(*os.File); !ok || !isTerminal(int(f.Fd())) {
+		e.terminal = false
+	}
+	if e.terminal {
+		e.reader = bufio.NewReader(in)
+	} else {
+		e.scanner = bufio.NewScanner(in)
+	}
+	e.loadHistory()
+	return e
+}
+
+// ReadLine prints prompt and returns the next line without its newline. It
+// returns io.EOF at the end of input or on Ctrl-D at an empty line, and
+// ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if !e.terminal {
+		fmt.Fprint(e.out, prompt)
+		if e.scanner.Scan() {
+			return e.scanner.Text(), nil
+		}
+		if err := e.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+
+	restore, err := makeRaw(int(e.in.Fd()))
+	if err != nil {
+		return "", err
+	}
+	e.mu.Lock()
+	e.restore = restore
+	e.mu.Unlock()
+	defer e.restoreTerminal()
+	return e.edit(prompt)
+}
+
+// Terminal reports whether the editor is reading from a terminal rather than
+// plain lines from a file or pipe.
+func (e *Editor) Terminal() bool {
+	return e.terminal
+}
+
+// restoreTerminal takes the terminal out of raw mode if a ReadLine put it
+// there.
+func (e *Editor) restoreTerminal() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.restore != nil {
+		e.restore()
+		e.restore = nil
+	}
+}
+
+// AddHistory records a line for recall, skipping blank lines and repeats of
+// the previous line.
+func (e *Editor) AddHistory(line string) error {
+	if strings.TrimSpace(line) == "" || (len(e.History) > 0 && e.History[len(e.History)-1] == line) {
+		return nil
+	}
+	e.History = append(e.History, line)
+	if len(e.History) > MaxHistory {
+		e.History = e.History[len(e.History)-MaxHistory:]
+	}
+
+	if e.historyFile == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(e.historyFile), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(e.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, line)
+	return err
+}
+
+// Close puts the terminal back the way it was, even when a ReadLine is in
+// progress, and rewrites the history file with only the most recent
+// MaxHistory lines.
+func (e *Editor) Close() error {
+	e.restoreTerminal()
+	if e.historyFile == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(e.historyFile), 0o755); err != nil {
+		return err
+	}
+	data := strings.Join(e.History, "\n")
+	if len(e.History) > 0 {
+		data += "\n"
+	}
+	tmp := e.historyFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(data), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.historyFile)
+}
+
+func (e *Editor) loadHistory() {
+	if e.historyFile == "" {
+		return
+	}
+	data, err := os.ReadFile(e.historyFile)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			e.History = append(e.History, line)
+		}
+	}
+	if len(e.History) > MaxHistory {
+		e.History = e.History[len(e.History)-MaxHistory:]
+	}
+}
+
+/*** Editing ***/
+// Keys
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyBackspace = 8
+	keyTab       = 9
+	keyCtrlJ     = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// Escape sequences are reported as runes past the Unicode range.
+const (
+	keyUp rune = 0x110000 + iota
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyForwardDelete
+	keyUnknown
+)
+
+// lineState is the line being edited and the cursor position within it.
+type lineState struct {
+	prompt  string
+	line    []rune
+	pos     int
+	history int
+	pending []rune
+}
+
+// edit runs the key loop for one line.
+func (e *Editor) edit(prompt string) (string, error) {
+	s := &lineState{prompt: prompt, history: len(e.History)}
+	e.refresh(s)
+
+	var previous rune
+	for {
+		key, err := e.readKey()
+		if err != nil {
+			return "", err
+		}
+		tabbedTwice := key == keyTab && previous == keyTab
+		previous = key
+		if key == keyCtrlR {
+			if key, err = e.search(s); err != nil {
+				return "", err
+			}
+		}
+
+		switch key {
+		case keyEnter, keyCtrlJ:
+			fmt.Fprint(e.out, "\r\n")
+			return string(s.line), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case keyBackspace, keyDelete:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAt(s.pos)
+			}
+		case keyForwardDelete:
+			s.deleteAt(s.pos)
+		case keyLeft, keyCtrlB:
+			s.pos = max(s.pos-1, 0)
+		case keyRight, keyCtrlF:
+			s.pos = min(s.pos+1, len(s.line))
+		case keyHome, keyCtrlA:
+			s.pos = 0
+		case keyEnd, keyCtrlE:
+			s.pos = len(s.line)
+		case keyUp, keyCtrlP:
+			e.recall(s, s.history-1)
+		case keyDown, keyCtrlN:
+			e.recall(s, s.history+1)
+		case keyCtrlK:
+			s.line = s.line[:s.pos]
+		case keyCtrlU:
+			s.line = s.line[s.pos:]
+			s.pos = 0
+		case keyCtrlW:
+			start := s.pos
+			for start > 0 && s.line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && s.line[start-1] != ' ' {
+				start--
+			}
+			s.line = append(s.line[:start], s.line[s.pos:]...)
+			s.pos = start
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyTab:
+			e.complete(s, tabbedTwice)
+		case keyCtrlG, keyEscape, keyUnknown:
+		default:
+			if key >= ' ' && key < keyUp {
+				s.insert(key)
+			}
+		}
+		e.refresh(s)
+	}
+}
+
+// recall replaces the line with entry i of the history, where the entry just
+// past the end is the line the user was typing.
+func (e *Editor) recall(s *lineState, i int) {
+	if i < 0 || i > len(e.History) {
+		return
+	}
+	if s.history == len(e.History) {
+		s.pending = s.line
+	}
+	s.history = i
+	if i == len(e.History) {
+		s.line = s.pending
+	} else {
+		s.line = []rune(e.History[i])
+	}
+	s.pos = len(s.line)
+}
+
+// search is Ctrl-R reverse incremental search. It returns the key that
+// ended the search once the match has been copied into the line, or Ctrl-G
+// if the search was cancelled.
+func (e *Editor) search(s *lineState) (rune, error) {
+	query := []rune{}
+	match := s.history
+	original, originalPos := s.line, s.pos
+
+	find := func(from int) {
+		for i := min(from, len(e.History)-1); i >= 0; i-- {
+			if strings.Contains(e.History[i], string(query)) {
+				match = i
+				s.line = []rune(e.History[i])
+				s.pos = len(s.line)
+				return
+			}
+		}
+	}
+
+	for {
+		fmt.Fprintf(e.out, "\r(reverse-i-search)`%s': %s\x1b[K", string(query), string(s.line))
+		key, err := e.readKey()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case key == keyCtrlR:
+			find(match - 1)
+		case key == keyBackspace || key == keyDelete:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				find(s.history - 1)
+			}
+		case key == keyCtrlG || key == keyEscape:
+			s.line, s.pos = original, originalPos
+			return keyCtrlG, nil
+		case key >= ' ' && key < keyUp:
+			query = append(query, key)
+			find(match)
+		default:
+			if match < len(e.History) {
+				s.history = match
+			}
+			return key, nil
+		}
+	}
+}
+
+// complete fills in the word under the cursor from the completer's
+// candidates: all of it for a single match, or as much as the matches share.
+// When that adds nothing, a second Tab lists the matches.
+func (e *Editor) complete(s *lineState, list bool) {
+	if e.Complete == nil {
+		return
+	}
+	start := s.pos
+	for start > 0 && s.line[start-1] != ' ' {
+		start--
+	}
+	word := string(s.line[start:s.pos])
+
+	matches := []string{}
+	for _, candidate := range e.Complete(string(s.line[:start])) {
+		if strings.HasPrefix(candidate, word) && !slices.Contains(matches, candidate) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+	slices.Sort(matches)
+
+	replacement := matches[0] + " "
+	if len(matches) > 1 {
+		replacement = commonPrefix(matches)
+	}
+	if replacement == word {
+		if list {
+			listed := matches[:min(len(matches), maxListed)]
+			fmt.Fprintf(e.out, "\r\n%s", strings.Join(listed, "  "))
+			if len(matches) > len(listed) {
+				fmt.Fprintf(e.out, "  (%d more)", len(matches)-len(listed))
+			}
+			fmt.Fprint(e.out, "\r\n")
+		}
+		return
+	}
+
+	tail := s.line[s.pos:]
+	line := append([]rune{}, s.line[:start]...)
+	line = append(line, []rune(replacement)...)
+	s.pos = len(line)
+	s.line = append(line, tail...)
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func (s *lineState) insert(r rune) {
+	s.line = append(s.line[:s.pos], append([]rune{r}, s.line[s.pos:]...)...)
+	s.pos++
+}
+
+func (s *lineState) deleteAt(i int) {
+	if i < len(s.line) {
+		s.line = append(s.line[:i], s.line[i+1:]...)
+	}
+}
+
+// refresh redraws the prompt and line and puts the cursor in place.
+func (e *Editor) refresh(s *lineState) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", s.prompt, string(s.line))
+	if back := len(s.line) - s.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+// readKey reads one key press, decoding arrow and editing keys from their
+// escape sequences.
+func (e *Editor) readKey() (rune, error) {
+	r, _, err := e.reader.ReadRune()
+	if err != nil || r != keyEscape {
+		return r, err
+	}
+	if e.reader.Buffered() == 0 {
+		return keyEscape, nil
+	}
+
+	next, _, err := e.reader.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if next != '[' && next != 'O' {
+		return keyUnknown, nil
+	}
+	code, _, err := e.reader.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	switch code {
+	case 'A':
+		return keyUp, nil
+	case 'B':
+		return keyDown, nil
+	case 'C':
+		return keyRight, nil
+	case 'D':
+		return keyLeft, nil
+	case 'H':
+		return keyHome, nil
+	case 'F':
+		return keyEnd, nil
+	}
+	if code < '0' || code > '9' {
+		return keyUnknown, nil
+	}
+
+	// Sequences such as ESC [ 3 ~ end in a tilde.
+	param := string(code)
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if r == '~' {
+			break
+		}
+		if r < '0' || r > ';' {
+			return keyUnknown, nil
+		}
+		param += string(r)
+	}
+	switch param {
+	case "1", "7":
+		return keyHome, nil
+	case "4", "8":
+		return keyEnd, nil
+	case "3":
+		return keyForwardDelete, nil
+	}
+	return keyUnknown, nil
+}
@@ -0,0 +1,159 @@
+package lineedit
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestEditor(input string, history ...string) *Editor {
+	return &Editor{
+		History: history,
+		out:     io.Discard,
+		reader:  bufio.NewReader(strings.NewReader(input)),
+	}
+}
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		history  []string
+		expected string
+	}{
+		{
+			name:     "typing",
+			input:    "explore\r",
+			expected: "explore",
+		},
+		{
+			name:     "cursor movement",
+			input:    "ctch\x1b[D\x1b[D\x1b[Da\r",
+			expected: "catch",
+		},
+		{
+			name:     "backspace and forward delete",
+			input:    "mapp\x7f\x1b[D\x1b[3~b\r",
+			expected: "mab",
+		},
+		{
+			name:     "home and end",
+			input:    "atch\x01c\x05 starly\r",
+			expected: "catch starly",
+		},
+		{
+			name:     "delete word",
+			input:    "catch pikachu\x17starly\r",
+			expected: "catch starly",
+		},
+		{
+			name:     "up arrow recall",
+			input:    "\x1b[A\x1b[A\r",
+			history:  []string{"map", "explore eterna-city-area"},
+			expected: "map",
+		},
+		{
+			name:     "down arrow returns to the typed line",
+			input:    "insp\x1b[A\x1b[Bect\r",
+			history:  []string{"map"},
+			expected: "inspect",
+		},
+		{
+			name:     "reverse search",
+			input:    "\x12cat\r",
+			history:  []string{"catch starly", "map", "catch bidoof", "mapb"},
+			expected: "catch bidoof",
+		},
+		{
+			name:     "reverse search again",
+			input:    "\x12cat\x12\r",
+			history:  []string{"catch starly", "map", "catch bidoof", "mapb"},
+			expected: "catch starly",
+		},
+		{
+			name:     "reverse search then edit",
+			input:    "\x12ex\x05-area\r",
+			history:  []string{"explore eterna-city"},
+			expected: "explore eterna-city-area",
+		},
+		{
+			name:     "cancelled search",
+			input:    "map\x12cat\x07b\r",
+			history:  []string{"catch starly"},
+			expected: "mapb",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := newTestEditor(c.input, c.history...).edit("Pokedex > ")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEditEndOfInput(t *testing.T) {
+	if _, err := newTestEditor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("expected io.EOF on Ctrl-D, got %v", err)
+	}
+	if _, err := newTestEditor("map\x03").edit("> "); err != ErrInterrupted {
+		t.Errorf("expected ErrInterrupted on Ctrl-C, got %v", err)
+	}
+}
+
+func TestHistoryPersists(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history")
+
+	e := &Editor{historyFile: file}
+	for _, line := range []string{"map", "map", "  ", "explore eterna-city-area"} {
+		if err := e.AddHistory(line); err != nil {
+			t.Fatalf("unable to add history: %v", err)
+		}
+	}
+
+	reopened := &Editor{historyFile: file}
+	reopened.loadHistory()
+	expected := []string{"map", "explore eterna-city-area"}
+	if strings.Join(reopened.History, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected history %v, got %v", expected, reopened.History)
+	}
+}
+
+func TestEditCompletion(t *testing.T) {
+	complete := func(head string) []string {
+		if head == "" {
+			return []string{"catch", "explore", "exit", "inspect"}
+		}
+		return []string{"eterna-city-area", "eterna-forest-area", "pastoria-city-area"}
+	}
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "cat\t\r", expected: "catch "},
+		{input: "e\ti\t\r", expected: "exit "},
+		{input: "ex\t\t\r", expected: "ex"},
+		{input: "explore et\t\r", expected: "explore eterna-"},
+		{input: "explore p\t\r", expected: "explore pastoria-city-area "},
+		{input: "explore x\t\r", expected: "explore x"},
+	}
+
+	for _, c := range cases {
+		e := newTestEditor(c.input)
+		e.Complete = complete
+		actual, err := e.edit("> ")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package lineedit
+
+import "errors"
+
+// Raw mode needs termios, so on other platforms the editor reads plain
+// lines.
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
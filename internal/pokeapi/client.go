@@ -0,0 +1,348 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/logan-waite/bootdev_pokedex/internal/pokecache"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
+// DefaultUserAgent identifies the Pokedex to PokeAPI.
+const DefaultUserAgent = "bootdev_pokedex (+https://github.com/logan-waite/bootdev_pokedex)"
+
+// Caching
+var apiCache = pokecache.NewCache(10 * time.Second)
+
+// Errors
+var ErrNotFound = errors.New("not found in PokeAPI")
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// Retries
+const maxAttempts = 3
+
+var retryBackoff = 250 * time.Millisecond
+
+// Requests a batch such as GetLocations makes at once
+const maxConcurrentRequests = 6
+
+// MaxBodySize caps how many bytes of a response are read, so a broken or
+// malicious upstream cannot exhaust memory.
+var MaxBodySize int64 = 8 << 20
+
+/*** Client ***/
+// Types
+
+// Client talks to one PokeAPI backend and holds the paging state of the
+// location commands. Resources that PokeAPI also serves over GraphQL go
+// through the client's transport; everything else uses the REST API.
+type Client struct {
+	baseURL           string
+	httpClient        *http.Client
+	userAgent         string
+	transport         transport
+	locationPaginator paginator
+	regionPaginator   regionPaginator
+}
+
+// transport fetches the results that more than one PokeAPI backend can
+// serve.
+type transport interface {
+	pokemon(ctx context.Context, name string) (Pokemon, error)
+	pokemonSummary(ctx context.Context, name string) (PokemonSummary, error)
+	locationArea(ctx context.Context, name string) (LocationArea, error)
+}
+
+type Option func(*Client)
+
+// WithBaseURL points the client's REST requests at another PokeAPI server,
+// or at an api-data directory with a file:// URL ending in /api/v2.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sends requests through an http.Client, for proxies,
+// timeouts or custom transports.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRoundTripper sends requests through a RoundTripper, such as a
+// LoggingTransport.
+func WithRoundTripper(roundTripper http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: roundTripper}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+func NewClient(options ...Option) *Client {
+	c := &Client{baseURL: DefaultBaseURL, httpClient: http.DefaultClient, userAgent: DefaultUserAgent}
+	c.transport = restTransport{client: c}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Fetch gets any PokeAPI resource by URL and decodes it into T, so following
+// a reference needs no hand-written Get* method.
+func Fetch[T any](ctx context.Context, c *Client, url string) (T, error) {
+	var result T
+	err := c.cachedGet(ctx, url, &result)
+	return result, err
+}
+
+// Resolve follows a NamedApiResource to the resource it refers to.
+func Resolve[T any](ctx context.Context, c *Client, resource NamedApiResource) (T, error) {
+	return Fetch[T](ctx, c, resource.Url)
+}
+
+// cachedGet decodes a resource into v, from the cache when it can and from
+// PokeAPI otherwise.
+func (c *Client) cachedGet(ctx context.Context, url string, v any) error {
+	url = c.resolveURL(url)
+	return c.cachedDo(ctx, url, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	}, v)
+}
+
+// cachedDo decodes the response to a request into v, retrying failures that
+// may be temporary. Responses are cached under key. When it fails, v is left
+// zeroed rather than half decoded.
+func (c *Client) cachedDo(ctx context.Context, key string, newRequest func() (*http.Request, error), v any) error {
+	if val, exists := apiCache.Get(key); exists {
+		err := decode(bytes.NewReader(val), key, v)
+		if err != nil {
+			reset(v)
+		}
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * retryBackoff):
+			}
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			return fmt.Errorf("invalid PokeAPI request: %w", err)
+		}
+		retry, err := c.do(req, key, v)
+		if err == nil {
+			return nil
+		}
+		reset(v)
+		if !retry {
+			return err
+		}
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// do makes a single request and decodes the body into v as it arrives,
+// keeping a copy for the cache. It reports whether a failed request is worth
+// retrying.
+func (c *Client) do(req *http.Request, key string, v any) (bool, error) {
+	url := req.URL.String()
+	req.Header.Set("User-Agent", c.userAgent)
+	var res *http.Response
+	var err error
+	if req.URL.Scheme == "file" {
+		res, err = mirrorTransport{}.RoundTrip(req)
+	} else {
+		res, err = c.httpClient.Do(req)
+	}
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		if !temporary(err) {
+			return false, fmt.Errorf("invalid PokeAPI request: %w", err)
+		}
+		return true, errors.New("error getting response from PokeAPI")
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return false, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return true, fmt.Errorf("PokeAPI responded with %s", res.Status)
+	case res.StatusCode != http.StatusOK:
+		return false, fmt.Errorf("PokeAPI responded with %s", res.Status)
+	}
+	if res.ContentLength > MaxBodySize {
+		return false, fmt.Errorf("%w: %s is %d bytes, over the %d byte limit", ErrBodyTooLarge, url, res.ContentLength, MaxBodySize)
+	}
+
+	var data bytes.Buffer
+	body := io.TeeReader(&limitedReader{r: res.Body, n: MaxBodySize}, &data)
+	err = decode(body, url, v)
+	if errors.Is(err, ErrBodyTooLarge) {
+		return false, fmt.Errorf("%w: %s is over the %d byte limit", ErrBodyTooLarge, url, MaxBodySize)
+	}
+	var parseErr *parseError
+	if errors.As(err, &parseErr) {
+		return false, err
+	}
+	if err != nil {
+		return true, errors.New("error reading data from response body")
+	}
+
+	apiCache.Add(key, data.Bytes())
+	return false, nil
+}
+
+// temporary reports whether a request that got no response might get one if
+// tried again: network errors and dropped connections might, but a malformed
+// URL or an unsupported scheme never will.
+func temporary(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// reset zeroes the value v points to.
+func reset(v any) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv.Elem().SetZero()
+	}
+}
+
+type parseError struct {
+	url string
+}
+
+func (e *parseError) Error() string {
+	return fmt.Sprintf("unable to parse JSON from %s", e.url)
+}
+
+// decode reads one JSON value into v. Malformed JSON is a parseError; any
+// other error came from reading r.
+func decode(r io.Reader, url string, v any) error {
+	err := json.NewDecoder(r).Decode(v)
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.EOF) {
+		return &parseError{url: url}
+	}
+	return err
+}
+
+// limitedReader reads at most n bytes, then fails with ErrBodyTooLarge if
+// there is anything left.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// FetchEach calls fetch for each resource, at most maxConcurrentRequests at a
+// time, and returns the results in order. The first error cancels the
+// fetches still running and is the one returned.
+func FetchEach[T any](ctx context.Context, resources []NamedApiResource, fetch func(context.Context, NamedApiResource) (T, error)) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]T, len(resources))
+	slots := make(chan struct{}, maxConcurrentRequests)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	for i, resource := range resources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+			defer func() { <-slots }()
+
+			result, err := fetch(ctx, resource)
+			if err != nil {
+				fail(err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+/*** REST Transport ***/
+type restTransport struct {
+	client *Client
+}
+
+func (t restTransport) pokemon(ctx context.Context, name string) (Pokemon, error) {
+	return getNamed[Pokemon](ctx, t.client, "pokemon", name)
+}
+
+func (t restTransport) pokemonSummary(ctx context.Context, name string) (PokemonSummary, error) {
+	return getNamed[PokemonSummary](ctx, t.client, "pokemon", name)
+}
+
+func (t restTransport) locationArea(ctx context.Context, name string) (LocationArea, error) {
+	return getNamed[LocationArea](ctx, t.client, "location-area", name)
+}
@@ -0,0 +1,467 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const DefaultGraphQLURL = "https://beta.pokeapi.co/graphql/v1beta"
+
+// WithGraphQL fetches pokemon, pokemon summaries and location areas from a
+// PokeAPI GraphQL endpoint, which returns a pokemon with its species and
+// moves in one round trip. Other resources still come from the REST API.
+func WithGraphQL(endpoint string) Option {
+	return func(c *Client) {
+		c.transport = graphQLTransport{client: c, endpoint: endpoint}
+	}
+}
+
+/*** GraphQL Transport ***/
+// Types
+type graphQLTransport struct {
+	client   *Client
+	endpoint string
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse[T any] struct {
+	Data   T `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type gqlResource struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// query runs a GraphQL query for one named resource, filtering on its id when
+// the name is numeric.
+func query[T any](ctx context.Context, t graphQLTransport, operation string, fields string, slug string) (T, error) {
+	var variables map[string]any
+	var filter, params string
+	if id, err := strconv.Atoi(slug); err == nil {
+		variables = map[string]any{"id": id}
+		filter, params = "id: {_eq: $id}", "$id: Int!"
+	} else {
+		variables = map[string]any{"name": slug}
+		filter, params = "name: {_eq: $name}", "$name: String!"
+	}
+	body, err := json.Marshal(graphQLRequest{
+		Query:     fmt.Sprintf("query %s(%s) { %s(where: {%s}, limit: 1) %s }", operation, params, operation, filter, fields),
+		Variables: variables,
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// The fields are part of the key, since a pokemon and its summary are
+	// the same operation.
+	var response graphQLResponse[T]
+	err = t.client.cachedDo(ctx, t.endpoint+"#"+operation+"/"+slug+" "+fields, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, &response)
+	if err != nil {
+		return response.Data, err
+	}
+	if len(response.Errors) > 0 {
+		messages := []string{}
+		for _, e := range response.Errors {
+			messages = append(messages, e.Message)
+		}
+		return response.Data, fmt.Errorf("PokeAPI GraphQL error: %s", strings.Join(messages, "; "))
+	}
+	return response.Data, nil
+}
+
+// resource builds the REST reference for a GraphQL row, so results look the
+// same whichever transport fetched them.
+func (t graphQLTransport) resource(kind string, r gqlResource) NamedApiResource {
+	if r.Name == "" {
+		return NamedApiResource{}
+	}
+	return NamedApiResource{Name: r.Name, Url: fmt.Sprintf("%s/%s/%d/", t.client.baseURL, kind, r.ID)}
+}
+
+/*** Pokemon ***/
+// Types
+const pokemonFields = `{
+	id name base_experience height weight order is_default
+	pokemon_v2_pokemonspecy { id name }
+	pokemon_v2_pokemonabilities { is_hidden slot pokemon_v2_ability { id name } }
+	pokemon_v2_pokemonabilitypasts { is_hidden slot pokemon_v2_ability { id name } pokemon_v2_generation { id name } }
+	pokemon_v2_pokemonstats { base_stat effort pokemon_v2_stat { id name } }
+	pokemon_v2_pokemontypes { slot pokemon_v2_type { id name } }
+	pokemon_v2_pokemontypepasts { slot pokemon_v2_type { id name } pokemon_v2_generation { id name } }
+	pokemon_v2_pokemonmoves { level order pokemon_v2_move { id name } pokemon_v2_versiongroup { id name } pokemon_v2_movelearnmethod { id name } }
+	pokemon_v2_pokemonitems { rarity pokemon_v2_item { id name } pokemon_v2_version { id name } }
+	pokemon_v2_pokemonforms { id name }
+	pokemon_v2_pokemongameindices { game_index pokemon_v2_version { id name } }
+	pokemon_v2_pokemonsprites { sprites }
+	pokemon_v2_pokemoncries { cries }
+}`
+
+// The fields of a PokemonSummary
+const pokemonSummaryFields = `{
+	id name base_experience height weight
+	pokemon_v2_pokemonspecy { id name }
+	pokemon_v2_pokemonstats { base_stat effort pokemon_v2_stat { id name } }
+	pokemon_v2_pokemontypes { slot pokemon_v2_type { id name } }
+}`
+
+type gqlPokemon struct {
+	ID             int         `json:"id"`
+	Name           string      `json:"name"`
+	BaseExperience int         `json:"base_experience"`
+	Height         int         `json:"height"`
+	Weight         int         `json:"weight"`
+	Order          int         `json:"order"`
+	IsDefault      bool        `json:"is_default"`
+	Species        gqlResource `json:"pokemon_v2_pokemonspecy"`
+	Abilities      []struct {
+		IsHidden bool        `json:"is_hidden"`
+		Slot     int         `json:"slot"`
+		Ability  gqlResource `json:"pokemon_v2_ability"`
+	} `json:"pokemon_v2_pokemonabilities"`
+	PastAbilities []struct {
+		IsHidden   bool        `json:"is_hidden"`
+		Slot       int         `json:"slot"`
+		Ability    gqlResource `json:"pokemon_v2_ability"`
+		Generation gqlResource `json:"pokemon_v2_generation"`
+	} `json:"pokemon_v2_pokemonabilitypasts"`
+	Stats []struct {
+		BaseStat int         `json:"base_stat"`
+		Effort   int         `json:"effort"`
+		Stat     gqlResource `json:"pokemon_v2_stat"`
+	} `json:"pokemon_v2_pokemonstats"`
+	Types []struct {
+		Slot int         `json:"slot"`
+		Type gqlResource `json:"pokemon_v2_type"`
+	} `json:"pokemon_v2_pokemontypes"`
+	PastTypes []struct {
+		Slot       int         `json:"slot"`
+		Type       gqlResource `json:"pokemon_v2_type"`
+		Generation gqlResource `json:"pokemon_v2_generation"`
+	} `json:"pokemon_v2_pokemontypepasts"`
+	Moves []struct {
+		Level        int         `json:"level"`
+		Order        int         `json:"order"`
+		Move         gqlResource `json:"pokemon_v2_move"`
+		VersionGroup gqlResource `json:"pokemon_v2_versiongroup"`
+		Method       gqlResource `json:"pokemon_v2_movelearnmethod"`
+	} `json:"pokemon_v2_pokemonmoves"`
+	Items []struct {
+		Rarity  int         `json:"rarity"`
+		Item    gqlResource `json:"pokemon_v2_item"`
+		Version gqlResource `json:"pokemon_v2_version"`
+	} `json:"pokemon_v2_pokemonitems"`
+	Forms       []gqlResource `json:"pokemon_v2_pokemonforms"`
+	GameIndices []struct {
+		GameIndex int         `json:"game_index"`
+		Version   gqlResource `json:"pokemon_v2_version"`
+	} `json:"pokemon_v2_pokemongameindices"`
+	Sprites []struct {
+		Sprites PokemonSprites `json:"sprites"`
+	} `json:"pokemon_v2_pokemonsprites"`
+	Cries []struct {
+		Cries PokemonCries `json:"cries"`
+	} `json:"pokemon_v2_pokemoncries"`
+}
+
+// pokemonRow fetches the fields of one pokemon.
+func (t graphQLTransport) pokemonRow(ctx context.Context, fields string, name string) (gqlPokemon, error) {
+	slug := Slug(name)
+	data, err := query[struct {
+		Pokemon []gqlPokemon `json:"pokemon_v2_pokemon"`
+	}](ctx, t, "pokemon_v2_pokemon", fields, slug)
+	if err != nil {
+		return gqlPokemon{}, err
+	}
+	if len(data.Pokemon) == 0 {
+		return gqlPokemon{}, notFound(ctx, t.client, "pokemon", slug)
+	}
+	return data.Pokemon[0], nil
+}
+
+func (t graphQLTransport) pokemonSummary(ctx context.Context, name string) (PokemonSummary, error) {
+	row, err := t.pokemonRow(ctx, pokemonSummaryFields, name)
+	if err != nil {
+		return PokemonSummary{}, err
+	}
+
+	summary := PokemonSummary{
+		ID:             row.ID,
+		Name:           row.Name,
+		BaseExperience: row.BaseExperience,
+		Height:         row.Height,
+		Weight:         row.Weight,
+		Species:        t.resource("pokemon-species", row.Species),
+	}
+	for _, stat := range row.Stats {
+		summary.Stats = append(summary.Stats, PokemonStat{
+			BaseStat: stat.BaseStat,
+			Effort:   stat.Effort,
+			Stat:     t.resource("stat", stat.Stat),
+		})
+	}
+	for _, pokemonType := range row.Types {
+		summary.Types = append(summary.Types, PokemonType{Slot: pokemonType.Slot, Type: t.resource("type", pokemonType.Type)})
+	}
+	return summary, nil
+}
+
+func (t graphQLTransport) pokemon(ctx context.Context, name string) (Pokemon, error) {
+	row, err := t.pokemonRow(ctx, pokemonFields, name)
+	if err != nil {
+		return Pokemon{}, err
+	}
+
+	pokemon := Pokemon{
+		ID:                     row.ID,
+		Name:                   row.Name,
+		BaseExperience:         row.BaseExperience,
+		Height:                 row.Height,
+		Weight:                 row.Weight,
+		Order:                  row.Order,
+		IsDefault:              row.IsDefault,
+		Species:                t.resource("pokemon-species", row.Species),
+		LocationAreaEncounters: fmt.Sprintf("%s/pokemon/%d/encounters", t.client.baseURL, row.ID),
+		// REST sends an empty list rather than null
+		Abilities:     []PokemonAbility{},
+		Forms:         []NamedApiResource{},
+		GameIndices:   []VersionGameIndex{},
+		HeldItems:     []PokemonHeldItem{},
+		Moves:         []PokemonMove{},
+		Stats:         []PokemonStat{},
+		Types:         []PokemonType{},
+		PastTypes:     []PokemonPastType{},
+		PastAbilities: []PokemonAbilityPast{},
+	}
+	for _, ability := range row.Abilities {
+		pokemon.Abilities = append(pokemon.Abilities, PokemonAbility{
+			IsHidden: ability.IsHidden,
+			Slot:     ability.Slot,
+			Ability:  t.resource("ability", ability.Ability),
+		})
+	}
+	for _, form := range row.Forms {
+		pokemon.Forms = append(pokemon.Forms, t.resource("pokemon-form", form))
+	}
+	for _, gameIndex := range row.GameIndices {
+		pokemon.GameIndices = append(pokemon.GameIndices, VersionGameIndex{GameIndex: gameIndex.GameIndex, Version: t.resource("version", gameIndex.Version)})
+	}
+	if len(row.Sprites) > 0 {
+		pokemon.Sprites = row.Sprites[0].Sprites
+	}
+	if len(row.Cries) > 0 {
+		pokemon.Cries = row.Cries[0].Cries
+	}
+	for _, stat := range row.Stats {
+		pokemon.Stats = append(pokemon.Stats, PokemonStat{
+			BaseStat: stat.BaseStat,
+			Effort:   stat.Effort,
+			Stat:     t.resource("stat", stat.Stat),
+		})
+	}
+	for _, pokemonType := range row.Types {
+		pokemon.Types = append(pokemon.Types, PokemonType{Slot: pokemonType.Slot, Type: t.resource("type", pokemonType.Type)})
+	}
+
+	pastTypes := map[string]int{}
+	for _, past := range row.PastTypes {
+		i, ok := pastTypes[past.Generation.Name]
+		if !ok {
+			i = len(pokemon.PastTypes)
+			pastTypes[past.Generation.Name] = i
+			pokemon.PastTypes = append(pokemon.PastTypes, PokemonPastType{Generation: t.resource("generation", past.Generation)})
+		}
+		pokemon.PastTypes[i].Types = append(pokemon.PastTypes[i].Types, PokemonType{Slot: past.Slot, Type: t.resource("type", past.Type)})
+	}
+
+	// A past ability with no ability is a slot the pokemon did not have yet,
+	// which REST also reports with a null ability.
+	pastAbilities := map[string]int{}
+	for _, past := range row.PastAbilities {
+		i, ok := pastAbilities[past.Generation.Name]
+		if !ok {
+			i = len(pokemon.PastAbilities)
+			pastAbilities[past.Generation.Name] = i
+			pokemon.PastAbilities = append(pokemon.PastAbilities, PokemonAbilityPast{Generation: t.resource("generation", past.Generation)})
+		}
+		pokemon.PastAbilities[i].Abilities = append(pokemon.PastAbilities[i].Abilities, PokemonAbility{
+			IsHidden: past.IsHidden,
+			Slot:     past.Slot,
+			Ability:  t.resource("ability", past.Ability),
+		})
+	}
+
+	moves := map[string]int{}
+	for _, move := range row.Moves {
+		i, ok := moves[move.Move.Name]
+		if !ok {
+			i = len(pokemon.Moves)
+			moves[move.Move.Name] = i
+			pokemon.Moves = append(pokemon.Moves, PokemonMove{Move: t.resource("move", move.Move)})
+		}
+		pokemon.Moves[i].VersionGroupDetails = append(pokemon.Moves[i].VersionGroupDetails, PokemonMoveVersion{
+			LevelLearnedAt:  move.Level,
+			VersionGroup:    t.resource("version-group", move.VersionGroup),
+			MoveLearnMethod: t.resource("move-learn-method", move.Method),
+			Order:           move.Order,
+		})
+	}
+
+	items := map[string]int{}
+	for _, item := range row.Items {
+		i, ok := items[item.Item.Name]
+		if !ok {
+			i = len(pokemon.HeldItems)
+			items[item.Item.Name] = i
+			pokemon.HeldItems = append(pokemon.HeldItems, PokemonHeldItem{Item: t.resource("item", item.Item)})
+		}
+		pokemon.HeldItems[i].VersionDetails = append(pokemon.HeldItems[i].VersionDetails, struct {
+			Rarity  int              `json:"rarity"`
+			Version NamedApiResource `json:"version"`
+		}{Rarity: item.Rarity, Version: t.resource("version", item.Version)})
+	}
+
+	return pokemon, nil
+}
+
+/*** Location Area ***/
+// Types
+const locationAreaFields = `{
+	id name game_index
+	pokemon_v2_location { id name }
+	pokemon_v2_locationareanames { name pokemon_v2_language { id name } }
+	pokemon_v2_locationareaencounterrates { rate pokemon_v2_encountermethod { id name } pokemon_v2_version { id name } }
+	pokemon_v2_encounters {
+		min_level max_level
+		pokemon_v2_pokemon { id name }
+		pokemon_v2_version { id name }
+		pokemon_v2_encounterslot { rarity pokemon_v2_encountermethod { id name } }
+		pokemon_v2_encounterconditionvaluemaps { pokemon_v2_encounterconditionvalue { id name } }
+	}
+}`
+
+type gqlLocationArea struct {
+	ID        int         `json:"id"`
+	Name      string      `json:"name"`
+	GameIndex int         `json:"game_index"`
+	Location  gqlResource `json:"pokemon_v2_location"`
+	Names     []struct {
+		Name     string      `json:"name"`
+		Language gqlResource `json:"pokemon_v2_language"`
+	} `json:"pokemon_v2_locationareanames"`
+	Rates []struct {
+		Rate    int         `json:"rate"`
+		Method  gqlResource `json:"pokemon_v2_encountermethod"`
+		Version gqlResource `json:"pokemon_v2_version"`
+	} `json:"pokemon_v2_locationareaencounterrates"`
+	Encounters []struct {
+		MinLevel int         `json:"min_level"`
+		MaxLevel int         `json:"max_level"`
+		Pokemon  gqlResource `json:"pokemon_v2_pokemon"`
+		Version  gqlResource `json:"pokemon_v2_version"`
+		Slot     struct {
+			Rarity int         `json:"rarity"`
+			Method gqlResource `json:"pokemon_v2_encountermethod"`
+		} `json:"pokemon_v2_encounterslot"`
+		Conditions []struct {
+			Value gqlResource `json:"pokemon_v2_encounterconditionvalue"`
+		} `json:"pokemon_v2_encounterconditionvaluemaps"`
+	} `json:"pokemon_v2_encounters"`
+}
+
+func (t graphQLTransport) locationArea(ctx context.Context, name string) (LocationArea, error) {
+	slug := Slug(name)
+	data, err := query[struct {
+		LocationAreas []gqlLocationArea `json:"pokemon_v2_locationarea"`
+	}](ctx, t, "pokemon_v2_locationarea", locationAreaFields, slug)
+	if err != nil {
+		return LocationArea{}, err
+	}
+	if len(data.LocationAreas) == 0 {
+		return LocationArea{}, notFound(ctx, t.client, "location-area", slug)
+	}
+	row := data.LocationAreas[0]
+
+	area := LocationArea{
+		Id:        row.ID,
+		Name:      row.Name,
+		GameIndex: row.GameIndex,
+		Location:  t.resource("location", row.Location),
+	}
+	for _, areaName := range row.Names {
+		area.Names = append(area.Names, Name{Name: areaName.Name, Language: t.resource("language", areaName.Language)})
+	}
+
+	methods := map[string]int{}
+	for _, rate := range row.Rates {
+		i, ok := methods[rate.Method.Name]
+		if !ok {
+			i = len(area.EncounterMethodRates)
+			methods[rate.Method.Name] = i
+			area.EncounterMethodRates = append(area.EncounterMethodRates, EncounterMethodRate{EncounterMethod: t.resource("encounter-method", rate.Method)})
+		}
+		area.EncounterMethodRates[i].VersionDetails = append(area.EncounterMethodRates[i].VersionDetails, EncounterVersionDetails{
+			Rate:    rate.Rate,
+			Version: t.resource("version", rate.Version),
+		})
+	}
+
+	// Each GraphQL row is one encounter slot; REST groups them by pokemon and
+	// then by version, with the version's chances summed.
+	pokemon := map[string]int{}
+	for _, slot := range row.Encounters {
+		i, ok := pokemon[slot.Pokemon.Name]
+		if !ok {
+			i = len(area.PokemonEncounters)
+			pokemon[slot.Pokemon.Name] = i
+			area.PokemonEncounters = append(area.PokemonEncounters, PokemonEncounter{Pokemon: t.resource("pokemon", slot.Pokemon)})
+		}
+		encounter := &area.PokemonEncounters[i]
+
+		j := -1
+		for k, details := range encounter.VersionDetails {
+			if details.Version.Name == slot.Version.Name {
+				j = k
+			}
+		}
+		if j < 0 {
+			j = len(encounter.VersionDetails)
+			encounter.VersionDetails = append(encounter.VersionDetails, VersionEncounterDetail{Version: t.resource("version", slot.Version)})
+		}
+
+		detail := Encounter{
+			MinLevel:        slot.MinLevel,
+			MaxLevel:        slot.MaxLevel,
+			ConditionValues: []NamedApiResource{},
+			Chance:          slot.Slot.Rarity,
+			Method:          t.resource("encounter-method", slot.Slot.Method),
+		}
+		for _, condition := range slot.Conditions {
+			detail.ConditionValues = append(detail.ConditionValues, t.resource("encounter-condition-value", condition.Value))
+		}
+		encounter.VersionDetails[j].MaxChance += detail.Chance
+		encounter.VersionDetails[j].EncounterDetails = append(encounter.VersionDetails[j].EncounterDetails, detail)
+	}
+
+	return area, nil
+}
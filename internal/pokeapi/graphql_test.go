@@ -0,0 +1,132 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newGraphQLServer stands in for the PokeAPI GraphQL endpoint, answering each
+// query with the canned response for its operation and name, then for its
+// operation, or with no rows.
+func newGraphQLServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var request graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("unable to parse GraphQL request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		operation, _, _ := strings.Cut(strings.Fields(request.Query)[1], "(")
+
+		data, err := os.ReadFile(fmt.Sprintf("testdata/graphql/%s-%v.json", operation, request.Variables["name"]))
+		if err != nil {
+			data, err = os.ReadFile("testdata/graphql/" + operation + ".json")
+		}
+		if err != nil || request.Variables["name"] == "missingno" {
+			fmt.Fprintf(w, `{"data": {%q: []}}`, operation)
+			return
+		}
+		w.Write(data)
+	}))
+}
+
+func TestGraphQLPokemonSummary(t *testing.T) {
+	server := newGraphQLServer(t)
+	defer server.Close()
+	// The server answers no REST requests, so only a GraphQL query can
+	// succeed.
+	client := NewClient(WithBaseURL(server.URL), WithGraphQL(server.URL))
+
+	summary, err := client.GetPokemonSummary(context.Background(), "Pikachu")
+	if err != nil {
+		t.Fatalf("unable to get pokemon summary: %v", err)
+	}
+	if summary.Name != "pikachu" || summary.BaseExperience != 112 {
+		t.Errorf("unexpected summary %v (%v)", summary.Name, summary.BaseExperience)
+	}
+	if len(summary.Types) != 1 || summary.Types[0].Type.Name != "electric" {
+		t.Errorf("expected an electric type, got %v", summary.Types)
+	}
+}
+
+func TestGraphQLLocationArea(t *testing.T) {
+	server := newGraphQLServer(t)
+	defer server.Close()
+	client := NewClient(WithGraphQL(server.URL))
+
+	area, err := client.GetLocationAreaData(context.Background(), "Sinnoh Route 201 Area")
+	if err != nil {
+		t.Fatalf("unable to get location area: %v", err)
+	}
+
+	data, _ := loadFixture(t, "location-area/sinnoh-route-201-area.json")
+	var expected LocationArea
+	if err := json.Unmarshal(data, &expected); err != nil {
+		t.Fatalf("unable to decode location area: %v", err)
+	}
+	if !reflect.DeepEqual(area, expected) {
+		t.Errorf("expected the GraphQL result to match REST\ngot:  %+v\nwant: %+v", area, expected)
+	}
+}
+
+func TestGraphQLPokemon(t *testing.T) {
+	server := newGraphQLServer(t)
+	defer server.Close()
+	client := NewClient(WithGraphQL(server.URL))
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("unable to get pokemon: %v", err)
+	}
+	if pokemon.Species.Url != DefaultBaseURL+"/pokemon-species/25/" {
+		t.Errorf("expected a REST species reference, got %v", pokemon.Species.Url)
+	}
+
+	data, _ := loadFixture(t, "pokemon/pikachu.json")
+	var expected Pokemon
+	if err := json.Unmarshal(data, &expected); err != nil {
+		t.Fatalf("unable to decode pokemon: %v", err)
+	}
+	if !reflect.DeepEqual(pokemon, expected) {
+		t.Errorf("expected the GraphQL result to match REST\ngot:  %+v\nwant: %+v", pokemon, expected)
+	}
+
+	var notFoundErr *NotFoundError
+	client = NewClient(WithBaseURL(server.URL), WithGraphQL(server.URL))
+	_, err = client.GetPokemon(context.Background(), "missingno")
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("expected a NotFoundError, got %v", err)
+	}
+}
+
+func TestGraphQLPastAbilities(t *testing.T) {
+	server := newGraphQLServer(t)
+	defer server.Close()
+	client := NewClient(WithGraphQL(server.URL))
+
+	pokemon, err := client.GetPokemon(context.Background(), "gengar")
+	if err != nil {
+		t.Fatalf("unable to get pokemon: %v", err)
+	}
+	expected := []PokemonAbilityPast{{
+		Generation: NamedApiResource{Name: "generation-vi", Url: DefaultBaseURL + "/generation/6/"},
+		Abilities: []PokemonAbility{
+			{Slot: 1, Ability: NamedApiResource{Name: "levitate", Url: DefaultBaseURL + "/ability/26/"}},
+		},
+	}}
+	if !reflect.DeepEqual(pokemon.PastAbilities, expected) {
+		t.Errorf("expected levitate before generation vii, got %+v", pokemon.PastAbilities)
+	}
+}
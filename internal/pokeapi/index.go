@@ -0,0 +1,193 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*** Name Index ***/
+// Types
+type storedIndex struct {
+	Count    int                `json:"count"`
+	Results  []NamedApiResource `json:"results"`
+	SyncedAt time.Time          `json:"synced_at"`
+}
+
+func (index storedIndex) complete() bool {
+	return index.Count > 0 && len(index.Results) >= index.Count
+}
+
+// expired reports whether an index is too old to trust. An index saved
+// before sync times were kept has none, so it is expired too.
+func (index storedIndex) expired() bool {
+	return time.Since(index.SyncedAt) > IndexMaxAge
+}
+
+// IndexDir is where name indexes are kept between sessions, in a directory
+// for each backend. Leave it empty to keep indexes in memory only.
+var IndexDir = defaultIndexDir()
+
+// IndexMaxAge is how long a stored index is used before PokeAPI is asked
+// again, so names added since are not reported missing forever.
+var IndexMaxAge = 30 * 24 * time.Hour
+
+const syncPageSize = 200
+
+// nameIndex holds the indexes used this session, keyed by their list URL so
+// two backends never share one.
+var nameIndex = struct {
+	mu        sync.Mutex
+	resources map[string][]NamedApiResource
+}{resources: map[string][]NamedApiResource{}}
+
+func defaultIndexDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "bootdev_pokedex", "index")
+}
+
+// GetIndex returns every name and id of a resource, such as "pokemon" or
+// "location-area". A synced index is used when there is one; otherwise the
+// list is fetched once and kept for the session. Only sync saves indexes.
+func (c *Client) GetIndex(ctx context.Context, resource string) ([]NamedApiResource, error) {
+	if results, ok := c.CachedIndex(resource); ok {
+		return results, nil
+	}
+
+	list, err := Fetch[NamedApiResourceList](ctx, c, c.baseURL+"/"+resource+"/?limit=100000")
+	if err != nil {
+		return nil, err
+	}
+	c.setIndex(resource, list.Results)
+	return list.Results, nil
+}
+
+// CachedIndex returns a resource's index from the client's backend without
+// going to the network, if it has been fetched this session or completely
+// synced less than IndexMaxAge ago.
+func (c *Client) CachedIndex(resource string) ([]NamedApiResource, bool) {
+	nameIndex.mu.Lock()
+	results, ok := nameIndex.resources[c.indexKey(resource)]
+	nameIndex.mu.Unlock()
+	if ok {
+		return results, true
+	}
+
+	index, err := c.loadIndex(resource)
+	if err != nil || !index.complete() || index.expired() {
+		return nil, false
+	}
+	c.setIndex(resource, index.Results)
+	return index.Results, true
+}
+
+// SyncIndex downloads a resource's full index to IndexDir a page at a time,
+// calling progress after each page. An interrupted sync picks up from the
+// last page it saved, and an expired index is downloaded again.
+func (c *Client) SyncIndex(ctx context.Context, resource string, progress func(done int, total int)) error {
+	if IndexDir == "" {
+		return fmt.Errorf("no cache directory to sync %s into", resource)
+	}
+
+	index, err := c.loadIndex(resource)
+	if err != nil {
+		return err
+	}
+	if index.expired() {
+		index = storedIndex{SyncedAt: time.Now()}
+	}
+
+	for !index.complete() {
+		url := fmt.Sprintf("%s/%s/?offset=%d&limit=%d", c.baseURL, resource, len(index.Results), syncPageSize)
+		page, err := Fetch[NamedApiResourceList](ctx, c, url)
+		if err != nil {
+			return err
+		}
+		if len(page.Results) == 0 {
+			break
+		}
+
+		index.Count = page.Count
+		index.Results = append(index.Results, page.Results...)
+		if err := c.saveIndex(resource, index); err != nil {
+			return err
+		}
+		progress(len(index.Results), index.Count)
+	}
+
+	if index.Count == 0 {
+		index.Count = len(index.Results)
+	}
+	progress(len(index.Results), index.Count)
+	c.setIndex(resource, index.Results)
+	return nil
+}
+
+func (c *Client) indexKey(resource string) string {
+	return c.baseURL + "/" + resource + "/"
+}
+
+func (c *Client) setIndex(resource string, results []NamedApiResource) {
+	nameIndex.mu.Lock()
+	defer nameIndex.mu.Unlock()
+	nameIndex.resources[c.indexKey(resource)] = results
+}
+
+// indexDir is where the indexes of the client's backend are stored. The base
+// URL is escaped whole, so every backend gets a directory of its own.
+func (c *Client) indexDir() string {
+	return filepath.Join(IndexDir, url.QueryEscape(c.baseURL))
+}
+
+func (c *Client) indexPath(resource string) string {
+	return filepath.Join(c.indexDir(), resource+".json")
+}
+
+// loadIndex reads a stored index; a missing file is an empty index.
+func (c *Client) loadIndex(resource string) (storedIndex, error) {
+	if IndexDir == "" {
+		return storedIndex{}, nil
+	}
+	data, err := os.ReadFile(c.indexPath(resource))
+	if os.IsNotExist(err) {
+		return storedIndex{}, nil
+	}
+	if err != nil {
+		return storedIndex{}, fmt.Errorf("unable to read %s index: %w", resource, err)
+	}
+
+	var index storedIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return storedIndex{}, nil
+	}
+	return index, nil
+}
+
+// saveIndex writes an index through a temporary file so an interrupted write
+// never leaves a truncated index behind.
+func (c *Client) saveIndex(resource string, index storedIndex) error {
+	if IndexDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.indexDir(), 0o755); err != nil {
+		return fmt.Errorf("unable to create index directory: %w", err)
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	tmp := c.indexPath(resource) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("unable to write %s index: %w", resource, err)
+	}
+	return os.Rename(tmp, c.indexPath(resource))
+}
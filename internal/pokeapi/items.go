@@ -0,0 +1,107 @@
+package pokeapi
+
+import (
+	"context"
+)
+
+/*** GetItem ***/
+// Types
+type ApiResource struct {
+	Url string `json:"url"`
+}
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedApiResource `json:"language"`
+}
+
+type VersionGroupFlavorText struct {
+	Text         string           `json:"text"`
+	Language     NamedApiResource `json:"language"`
+	VersionGroup NamedApiResource `json:"version_group"`
+}
+
+type GenerationGameIndex struct {
+	GameIndex  int              `json:"game_index"`
+	Generation NamedApiResource `json:"generation"`
+}
+
+type ItemSprites struct {
+	Default string `json:"default"`
+}
+
+type ItemHolderPokemon struct {
+	Pokemon        NamedApiResource `json:"pokemon"`
+	VersionDetails []struct {
+		Rarity  int              `json:"rarity"`
+		Version NamedApiResource `json:"version"`
+	} `json:"version_details"`
+}
+
+type MachineVersionDetail struct {
+	Machine      ApiResource      `json:"machine"`
+	VersionGroup NamedApiResource `json:"version_group"`
+}
+
+type Item struct {
+	ID                int                      `json:"id"`
+	Name              string                   `json:"name"`
+	Cost              int                      `json:"cost"`
+	FlingPower        int                      `json:"fling_power"`
+	FlingEffect       NamedApiResource         `json:"fling_effect"`
+	Attributes        []NamedApiResource       `json:"attributes"`
+	Category          NamedApiResource         `json:"category"`
+	EffectEntries     []VerboseEffect          `json:"effect_entries"`
+	FlavorTextEntries []VersionGroupFlavorText `json:"flavor_text_entries"`
+	GameIndices       []GenerationGameIndex    `json:"game_indices"`
+	Names             []Name                   `json:"names"`
+	Sprites           ItemSprites              `json:"sprites"`
+	HeldByPokemon     []ItemHolderPokemon      `json:"held_by_pokemon"`
+	BabyTriggerFor    ApiResource              `json:"baby_trigger_for"`
+	Machines          []MachineVersionDetail   `json:"machines"`
+}
+
+func (c *Client) GetItem(ctx context.Context, item string) (Item, error) {
+	return getNamed[Item](ctx, c, "item", item)
+}
+
+/*** GetBerry ***/
+// Types
+type BerryFlavorMap struct {
+	Potency int              `json:"potency"`
+	Flavor  NamedApiResource `json:"flavor"`
+}
+
+type Berry struct {
+	ID               int              `json:"id"`
+	Name             string           `json:"name"`
+	GrowthTime       int              `json:"growth_time"`
+	MaxHarvest       int              `json:"max_harvest"`
+	NaturalGiftPower int              `json:"natural_gift_power"`
+	Size             int              `json:"size"`
+	Smoothness       int              `json:"smoothness"`
+	SoilDryness      int              `json:"soil_dryness"`
+	Firmness         NamedApiResource `json:"firmness"`
+	Flavors          []BerryFlavorMap `json:"flavors"`
+	Item             NamedApiResource `json:"item"`
+	NaturalGiftType  NamedApiResource `json:"natural_gift_type"`
+}
+
+func (c *Client) GetBerry(ctx context.Context, berry string) (Berry, error) {
+	return getNamed[Berry](ctx, c, "berry", berry)
+}
+
+/*** GetItemCategory ***/
+// Types
+type ItemCategory struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Items  []NamedApiResource `json:"items"`
+	Names  []Name             `json:"names"`
+	Pocket NamedApiResource   `json:"pocket"`
+}
+
+func (c *Client) GetItemCategory(ctx context.Context, category string) (ItemCategory, error) {
+	return getNamed[ItemCategory](ctx, c, "item-category", category)
+}
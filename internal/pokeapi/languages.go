@@ -0,0 +1,78 @@
+package pokeapi
+
+import (
+	"context"
+	"strings"
+)
+
+/*** GetLanguage ***/
+// Types
+type Language struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Official bool   `json:"official"`
+	Iso639   string `json:"iso639"`
+	Iso3166  string `json:"iso3166"`
+	Names    []Name `json:"names"`
+}
+
+func (c *Client) GetLanguage(ctx context.Context, language string) (Language, error) {
+	return getNamed[Language](ctx, c, "language", language)
+}
+
+/*** Localization ***/
+const DefaultLanguage = "en"
+
+// LocalizedName returns the name for a language, falling back to English and
+// then to fallback when neither is present.
+func LocalizedName(names []Name, language string, fallback string) string {
+	english := ""
+	for _, name := range names {
+		if name.Language.Name == language && name.Name != "" {
+			return name.Name
+		}
+		if name.Language.Name == DefaultLanguage {
+			english = name.Name
+		}
+	}
+	if english != "" {
+		return english
+	}
+	return fallback
+}
+
+// LocalizedGenus returns the genus for a language, falling back to English.
+func LocalizedGenus(genera []Genus, language string) string {
+	english := ""
+	for _, genus := range genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+		if genus.Language.Name == DefaultLanguage {
+			english = genus.Genus
+		}
+	}
+	return english
+}
+
+// LocalizedFlavorText returns the flavor text for a language, preferring the
+// entry for version when there is one, and falling back to English. Game
+// text line breaks are collapsed into spaces.
+func LocalizedFlavorText(entries []FlavorText, language string, version string) string {
+	text := ""
+	for _, lang := range []string{language, DefaultLanguage} {
+		for _, entry := range entries {
+			if entry.Language.Name != lang {
+				continue
+			}
+			text = entry.FlavorText
+			if entry.Version.Name == version {
+				break
+			}
+		}
+		if text != "" {
+			break
+		}
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
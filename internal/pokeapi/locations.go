@@ -0,0 +1,98 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+)
+
+/*** GetRegion ***/
+// Types
+type Region struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Locations      []NamedApiResource `json:"locations"`
+	MainGeneration NamedApiResource   `json:"main_generation"`
+	Names          []Name             `json:"names"`
+	Pokedexes      []NamedApiResource `json:"pokedexes"`
+	VersionGroups  []NamedApiResource `json:"version_groups"`
+}
+
+func (c *Client) GetRegion(ctx context.Context, region string) (Region, error) {
+	return getNamed[Region](ctx, c, "region", region)
+}
+
+/*** GetLocation ***/
+// Types
+type Location struct {
+	ID          int                   `json:"id"`
+	Name        string                `json:"name"`
+	Region      NamedApiResource      `json:"region"`
+	Names       []Name                `json:"names"`
+	GameIndices []GenerationGameIndex `json:"game_indices"`
+	Areas       []NamedApiResource    `json:"areas"`
+}
+
+func (c *Client) GetLocation(ctx context.Context, location string) (Location, error) {
+	return getNamed[Location](ctx, c, "location", location)
+}
+
+// GetLocations fetches several locations at once and returns them in the
+// order given.
+func (c *Client) GetLocations(ctx context.Context, locations []NamedApiResource) ([]Location, error) {
+	return FetchEach(ctx, locations, func(ctx context.Context, location NamedApiResource) (Location, error) {
+		return c.GetLocation(ctx, location.Name)
+	})
+}
+
+/*** GetRegionLocationAreas ***/
+// Types
+type regionPaginator struct {
+	region string
+	offset int
+}
+
+const regionPageSize = 20
+
+// GetRegionLocationAreas pages through the location areas of a region,
+// regionPageSize locations at a time. Switching regions starts over at the
+// first page.
+func (c *Client) GetRegionLocationAreas(ctx context.Context, region string, paginate string) ([]NamedApiResource, error) {
+	result, err := c.GetRegion(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if c.regionPaginator.region == result.Name {
+		offset = c.regionPaginator.offset
+		if paginate == "next" {
+			offset += regionPageSize
+		} else if paginate == "prev" {
+			offset -= regionPageSize
+		}
+	} else if paginate == "prev" {
+		return nil, fmt.Errorf("No previous map to return to; use `map %s` instead", result.Name)
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("No previous map to return to; use `map %s` instead", result.Name)
+	}
+	if offset >= len(result.Locations) && offset > 0 {
+		return nil, fmt.Errorf("No more locations in %s; use `mapb` instead", result.Name)
+	}
+
+	end := min(offset+regionPageSize, len(result.Locations))
+	locations, err := c.GetLocations(ctx, result.Locations[offset:end])
+	if err != nil {
+		return nil, err
+	}
+	areas := []NamedApiResource{}
+	for _, location := range locations {
+		areas = append(areas, location.Areas...)
+	}
+
+	c.regionPaginator.region = result.Name
+	c.regionPaginator.offset = offset
+
+	return areas, nil
+}
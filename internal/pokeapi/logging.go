@@ -0,0 +1,67 @@
+package pokeapi
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+/*** Request Logging ***/
+// Types
+
+// LoggingTransport is an http.RoundTripper that logs the method, URL,
+// status, body size and latency of every request it passes on to Next. The
+// entry is written once the body is closed, so the size and latency cover
+// the whole response. A nil Next sends requests through
+// http.DefaultTransport, and a nil Logger logs to log.Default().
+type LoggingTransport struct {
+	Next   http.RoundTripper
+	Logger *log.Logger
+}
+
+func (t LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	logger := t.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	start := time.Now()
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		logger.Printf("%s %s failed after %v: %v", req.Method, req.URL, time.Since(start).Round(time.Millisecond), err)
+		return nil, err
+	}
+
+	res.Body = &loggedBody{ReadCloser: res.Body, done: func(bytes int64) {
+		logger.Printf("%s %s %d %dB %v", req.Method, req.URL, res.StatusCode, bytes, time.Since(start).Round(time.Millisecond))
+	}}
+	return res, nil
+}
+
+// loggedBody counts the bytes read from a response body and reports them
+// when the body is closed.
+type loggedBody struct {
+	io.ReadCloser
+	bytes int64
+	done  func(bytes int64)
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes += int64(n)
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.done != nil {
+		b.done(b.bytes)
+		b.done = nil
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	const body = `{"name": "static", "url": "https://pokeapi.co/api/v2/ability/9/"}`
+	userAgents := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := NewClient(
+		WithRoundTripper(LoggingTransport{Logger: log.New(&logs, "", 0)}),
+		WithUserAgent("pokedex-test/1.0"),
+	)
+
+	url := server.URL + "/ability/9/"
+	if _, err := Fetch[NamedApiResource](context.Background(), client, url); err != nil {
+		t.Fatalf("unable to fetch: %v", err)
+	}
+
+	if len(userAgents) != 1 || userAgents[0] != "pokedex-test/1.0" {
+		t.Errorf("expected the custom User-Agent, got %v", userAgents)
+	}
+	entry := logs.String()
+	for _, expected := range []string{"GET " + url, " 200 ", fmt.Sprintf(" %dB ", len(body))} {
+		if !strings.Contains(entry, expected) {
+			t.Errorf("expected %q in log entry %q", expected, entry)
+		}
+	}
+}
+
+func TestLoggingTransportDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	defer func(w io.Writer) { log.SetOutput(w) }(log.Writer())
+	log.SetOutput(&logs)
+
+	client := NewClient(WithRoundTripper(LoggingTransport{}))
+	if _, err := Fetch[NamedApiResource](context.Background(), client, server.URL+"/ability/9/"); err != nil {
+		t.Fatalf("unable to fetch: %v", err)
+	}
+	if !strings.Contains(logs.String(), "GET "+server.URL+"/ability/9/ 200 ") {
+		t.Errorf("expected the request in the standard log, got %q", logs.String())
+	}
+}
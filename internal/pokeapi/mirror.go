@@ -0,0 +1,166 @@
+package pokeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const apiPath = "/api/v2"
+
+// WithDirectory reads from a local copy of the PokeAPI api-data repository
+// instead of over HTTP. dir is the repository's data directory, the one that
+// holds api/v2/pokemon/25/index.json.
+func WithDirectory(dir string) Option {
+	return func(c *Client) {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		c.baseURL = fileURL(filepath.ToSlash(dir)) + apiPath
+	}
+}
+
+// fileURL turns a slash-separated absolute path into a file:// URL. A Windows
+// path such as C:/data gets a leading slash, giving file:///C:/data, or its
+// drive letter would be read as the URL's host.
+func fileURL(dir string) string {
+	if !strings.HasPrefix(dir, "/") {
+		dir = "/" + dir
+	}
+	return (&url.URL{Scheme: "file", Path: dir}).String()
+}
+
+// localPath turns the path of a file:// URL back into a local path, dropping
+// the slash fileURL put in front of a Windows drive letter.
+func localPath(urlPath string) string {
+	if len(urlPath) > 1 && filepath.VolumeName(filepath.FromSlash(urlPath[1:])) != "" {
+		urlPath = urlPath[1:]
+	}
+	return filepath.FromSlash(urlPath)
+}
+
+// resolveURL turns the site-relative references found in api-data files, such
+// as "/api/v2/pokemon/25/", into URLs on the client's backend.
+func (c *Client) resolveURL(ref string) string {
+	if !strings.HasPrefix(ref, "/") {
+		return ref
+	}
+	root, _, found := strings.Cut(c.baseURL, apiPath)
+	if !found {
+		root = strings.TrimSuffix(c.baseURL, "/")
+	}
+	return root + ref
+}
+
+/*** Mirror ***/
+// Types
+
+// mirrorTransport answers file:// requests from an api-data directory. Names
+// are looked up in each resource's list, since the files are stored by id.
+type mirrorTransport struct{}
+
+func (mirrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dir := path.Clean(req.URL.Path)
+	parts := strings.Split(strings.TrimPrefix(dir, "/"), "/")
+
+	resolved := "/"
+	for _, part := range parts {
+		next := path.Join(resolved, part)
+		if _, err := os.Stat(localPath(next)); err != nil {
+			id, ok := lookupID(resolved, part)
+			if !ok {
+				return mirrorResponse(req, http.StatusNotFound, []byte("Not Found")), nil
+			}
+			next = path.Join(resolved, id)
+		}
+		resolved = next
+	}
+
+	data, err := os.ReadFile(localPath(path.Join(resolved, "index.json")))
+	if os.IsNotExist(err) {
+		return mirrorResponse(req, http.StatusNotFound, []byte("Not Found")), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Query().Has("offset") || req.URL.Query().Has("limit") {
+		data, err = pageList(data, req.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mirrorResponse(req, http.StatusOK, data), nil
+}
+
+// lookupID finds the id of a named resource in the list stored in dir.
+func lookupID(dir string, name string) (string, bool) {
+	data, err := os.ReadFile(localPath(path.Join(dir, "index.json")))
+	if err != nil {
+		return "", false
+	}
+	var list NamedApiResourceList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return "", false
+	}
+	for _, entry := range list.Results {
+		if entry.Name == name {
+			return strconv.Itoa(entry.ID()), true
+		}
+	}
+	return "", false
+}
+
+// pageList applies offset and limit to a stored list, which api-data keeps
+// as a single page.
+func pageList(data []byte, u *url.URL) ([]byte, error) {
+	var list NamedApiResourceList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	offset, _ := strconv.Atoi(u.Query().Get("offset"))
+	limit, err := strconv.Atoi(u.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	all := list.Results
+	offset = min(max(offset, 0), len(all))
+	end := min(offset+limit, len(all))
+	list.Results = all[offset:end]
+	list.Count = len(all)
+	list.Next, list.Previous = "", ""
+	page := u.Path
+	if i := strings.LastIndex(page, apiPath); i >= 0 {
+		page = page[i:]
+	}
+	if end < len(all) {
+		list.Next = fmt.Sprintf("%s?offset=%d&limit=%d", page, end, limit)
+	}
+	if offset > 0 {
+		list.Previous = fmt.Sprintf("%s?offset=%d&limit=%d", page, max(offset-limit, 0), limit)
+	}
+	return json.Marshal(list)
+}
+
+func mirrorResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,114 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// newMirror lays out a small api-data directory: pikachu with its encounters,
+// and 25 location areas.
+func newMirror(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(name string, data []byte) {
+		path := filepath.Join(dir, "api", "v2", filepath.FromSlash(name), "index.json")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeList := func(name string, results []NamedApiResource) {
+		data, err := json.Marshal(NamedApiResourceList{Count: len(results), Results: results})
+		if err != nil {
+			t.Fatal(err)
+		}
+		write(name, data)
+	}
+
+	pokemon, _ := loadFixture(t, "pokemon/pikachu.json")
+	encounters, _ := loadFixture(t, "pokemon/starly-encounters.json")
+	write("pokemon/25", pokemon)
+	write("pokemon/25/encounters", encounters)
+	writeList("pokemon", []NamedApiResource{
+		{Name: "pichu", Url: "/api/v2/pokemon/172/"},
+		{Name: "pikachu", Url: "/api/v2/pokemon/25/"},
+	})
+
+	areas := []NamedApiResource{}
+	for i := 1; i <= 25; i++ {
+		areas = append(areas, NamedApiResource{Name: fmt.Sprintf("area-%d", i), Url: fmt.Sprintf("/api/v2/location-area/%d/", i)})
+	}
+	writeList("location-area", areas)
+	return dir
+}
+
+func TestMirror(t *testing.T) {
+	isolateIndex(t)
+	client := NewClient(WithDirectory(newMirror(t)))
+
+	for _, name := range []string{"Pikachu", "25"} {
+		pokemon, err := client.GetPokemon(context.Background(), name)
+		if err != nil || pokemon.Name != "pikachu" {
+			t.Errorf("%v: expected pikachu, got %v, %v", name, pokemon.Name, err)
+		}
+	}
+
+	encounters, err := client.GetPokemonEncounters(context.Background(), "pikachu")
+	if err != nil || len(encounters) != 2 {
+		t.Errorf("expected 2 encounters, got %v, %v", len(encounters), err)
+	}
+
+	pokemon, err := Resolve[Pokemon](context.Background(), client, NamedApiResource{Name: "pikachu", Url: "/api/v2/pokemon/25/"})
+	if err != nil || pokemon.ID != 25 {
+		t.Errorf("expected a relative reference to resolve, got %v, %v", pokemon.ID, err)
+	}
+
+	first, err := client.GetLocationAreas(context.Background(), "next")
+	if err != nil || len(first) != 20 || first[0].Name != "area-1" {
+		t.Fatalf("expected the first 20 areas, got %v, %v", len(first), err)
+	}
+	second, err := client.GetLocationAreas(context.Background(), "next")
+	if err != nil || len(second) != 5 || second[0].Name != "area-21" {
+		t.Fatalf("expected the last 5 areas, got %v, %v", len(second), err)
+	}
+	back, err := client.GetLocationAreas(context.Background(), "prev")
+	if err != nil || len(back) != 20 || back[0].Name != "area-1" {
+		t.Errorf("expected to page back to the first 20 areas, got %v, %v", len(back), err)
+	}
+
+	_, err = client.GetPokemon(context.Background(), "pikachoo")
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) || len(notFoundErr.Suggestions) == 0 || notFoundErr.Suggestions[0] != "pikachu" {
+		t.Errorf("expected pikachu to be suggested, got %v", err)
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	cases := map[string]string{
+		"/srv/api-data/data": "file:///srv/api-data/data",
+		"C:/api-data/data":   "file:///C:/api-data/data",
+	}
+	for dir, expected := range cases {
+		actual := fileURL(dir)
+		if actual != expected {
+			t.Errorf("%v: expected %v, got %v", dir, expected, actual)
+		}
+		u, err := url.Parse(actual)
+		if err != nil || u.Host != "" || u.Path != "/"+strings.TrimPrefix(dir, "/") {
+			t.Errorf("%v: expected no host and the whole path, got %q and %q", dir, u.Host, u.Path)
+		}
+	}
+	if runtime.GOOS == "windows" && localPath("/C:/api-data/data") != `C:\api-data\data` {
+		t.Errorf("expected the drive letter's slash to be dropped, got %v", localPath("/C:/api-data/data"))
+	}
+}
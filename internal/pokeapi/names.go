@@ -0,0 +1,209 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*** Name Normalization ***/
+var accents = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ä': "a", 'ã': "a", 'å': "a",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'ö': "o", 'õ': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'♀': "-f", '♂': "-m",
+}
+
+// Slug turns a name the way a user might type it into the form PokeAPI uses
+// in its URLs, so "Mr. Mime" becomes "mr-mime", "Farfetch'd" becomes
+// "farfetchd" and "Flabébé" becomes "flabebe". Numeric ids lose any leading
+// zeros.
+func Slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+			// dropped, as in "farfetchd"
+		case accents[r] != "":
+			b.WriteString(accents[r])
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '-' })
+	slug := strings.Join(parts, "-")
+	if isID(slug) {
+		slug = strings.TrimLeft(slug, "0")
+	}
+	return slug
+}
+
+func isID(slug string) bool {
+	if slug == "" {
+		return false
+	}
+	for _, r := range slug {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+/*** Suggestions ***/
+const maxSuggestions = 3
+
+// NotFoundError reports a name PokeAPI does not know, along with the closest
+// names it does.
+type NotFoundError struct {
+	Resource    string
+	Name        string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("no %s named %q", e.Resource, e.Name)
+	switch len(e.Suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s; did you mean %s?", msg, e.Suggestions[0])
+	default:
+		last := len(e.Suggestions) - 1
+		return fmt.Sprintf("%s; did you mean %s or %s?", msg, strings.Join(e.Suggestions[:last], ", "), e.Suggestions[last])
+	}
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// Suggest returns up to three candidates close to name by edit distance,
+// closest first.
+func Suggest(name string, candidates []string) []string {
+	type scored struct {
+		name     string
+		distance int
+	}
+	limit := max(2, len(name)/3)
+	matches := []scored{}
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+		if distance <= limit {
+			matches = append(matches, scored{candidate, distance})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between two strings.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// getNamed fetches a resource by a user-supplied name or id. When PokeAPI has
+// no such resource the error suggests the closest known names.
+func getNamed[T any](ctx context.Context, c *Client, resource string, name string) (T, error) {
+	slug := Slug(name)
+	if slug == "" {
+		var zero T
+		return zero, &NotFoundError{Resource: resource, Name: name}
+	}
+
+	// A name missing from an index already at hand is not worth a request.
+	if index, ok := c.CachedIndex(resource); ok && !isID(slug) {
+		canonical, found := canonicalName(slug, index)
+		if !found {
+			var zero T
+			return zero, suggestFrom(resource, slug, index)
+		}
+		slug = canonical
+	}
+
+	result, err := Fetch[T](ctx, c, c.baseURL+"/"+resource+"/"+slug)
+	if !errors.Is(err, ErrNotFound) {
+		return result, err
+	}
+	if isID(slug) {
+		return result, &NotFoundError{Resource: resource, Name: slug}
+	}
+
+	index, indexErr := c.GetIndex(ctx, resource)
+	if indexErr != nil {
+		return result, &NotFoundError{Resource: resource, Name: slug}
+	}
+	if canonical, found := canonicalName(slug, index); found && canonical != slug {
+		return Fetch[T](ctx, c, c.baseURL+"/"+resource+"/"+canonical)
+	}
+	return result, suggestFrom(resource, slug, index)
+}
+
+// canonicalName finds slug in index regardless of case, since Slug lowercases
+// everything but a few names, such as the language ja-Hrkt, are not.
+func canonicalName(slug string, index []NamedApiResource) (string, bool) {
+	for _, entry := range index {
+		if strings.EqualFold(entry.Name, slug) {
+			return entry.Name, true
+		}
+	}
+	return "", false
+}
+
+func notFound(ctx context.Context, c *Client, resource string, slug string) error {
+	if isID(slug) {
+		return &NotFoundError{Resource: resource, Name: slug}
+	}
+	index, err := c.GetIndex(ctx, resource)
+	if err != nil {
+		return &NotFoundError{Resource: resource, Name: slug}
+	}
+	return suggestFrom(resource, slug, index)
+}
+
+// suggestFrom reports slug as not found, suggesting the closest names in
+// index.
+func suggestFrom(resource string, slug string, index []NamedApiResource) error {
+	names := []string{}
+	for _, entry := range index {
+		names = append(names, entry.Name)
+	}
+	return &NotFoundError{Resource: resource, Name: slug, Suggestions: Suggest(slug, names)}
+}
@@ -1,43 +1,13 @@
 package pokeapi
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"github.com/logan-waite/bootdev_pokedex/internal/pokecache"
-	"io"
-	"net/http"
-	"time"
+	"strconv"
+	"strings"
 )
 
-var BASE_URL = "https://pokeapi.co/api/v2"
-
-// Caching
-var apiCache = pokecache.NewCache(10 * time.Second)
-
-func cachedGet(url string) ([]byte, error) {
-	var data []byte
-	if val, exists := apiCache.Get(url); exists {
-		data = val
-	} else {
-		res, err := http.Get(url)
-		if err != nil {
-			return nil, errors.New("error getting response from PokeAPI")
-		}
-		defer res.Body.Close()
-
-		val, err := io.ReadAll(res.Body)
-		if err != nil {
-			return nil, errors.New("error reading data from response body")
-		}
-
-		data = val
-		apiCache.Add(url, data)
-	}
-
-	return data, nil
-}
-
 /*** GetLocationAreas ***/
 // Types
 type paginator struct {
@@ -50,6 +20,17 @@ type NamedApiResource struct {
 	Url  string `json:"url"`
 }
 
+// ID returns the numeric id at the end of the resource's URL, or 0 if the URL
+// does not end in one.
+func (resource NamedApiResource) ID() int {
+	parts := strings.Split(strings.TrimSuffix(resource.Url, "/"), "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
 type NamedApiResourceList struct {
 	Count    int                `json:"count"`
 	Next     string             `json:"next"`
@@ -57,33 +38,30 @@ type NamedApiResourceList struct {
 	Results  []NamedApiResource `json:"results"`
 }
 
-var locationPaginator = paginator{}
-
-func GetLocationAreas(paginate string) ([]NamedApiResource, error) {
-	url := BASE_URL + "/location-area/"
-	if paginate == "next" && locationPaginator != (paginator{}) {
-		url = locationPaginator.next
+func (c *Client) GetLocationAreas(ctx context.Context, paginate string) ([]NamedApiResource, error) {
+	url := c.baseURL + "/location-area/?offset=0&limit=20"
+	if paginate == "next" && c.locationPaginator != (paginator{}) {
+		if c.locationPaginator.next == "" {
+			return nil, fmt.Errorf("No more location areas; use `mapb` instead")
+		}
+		url = c.locationPaginator.next
 	} else if paginate == "prev" {
-		if locationPaginator != (paginator{}) && locationPaginator.previous == "" {
+		if c.locationPaginator == (paginator{}) {
+			return nil, fmt.Errorf("No previous map to return to; use `map` first")
+		}
+		if c.locationPaginator.previous == "" {
 			return nil, fmt.Errorf("No previous map to return to; use `map` instead")
-		} else {
-			url = locationPaginator.previous
 		}
+		url = c.locationPaginator.previous
 	}
 
-	data, err := cachedGet(url)
+	locations, err := Fetch[NamedApiResourceList](ctx, c, url)
 	if err != nil {
 		return nil, err
 	}
 
-	var locations NamedApiResourceList
-	err = json.Unmarshal(data, &locations)
-	if err != nil {
-		return nil, errors.New("unable to parse location-area JSON")
-	}
-
-	locationPaginator.next = locations.Next
-	locationPaginator.previous = locations.Previous
+	c.locationPaginator.next = locations.Next
+	c.locationPaginator.previous = locations.Previous
 
 	return locations.Results, nil
 }
@@ -91,11 +69,11 @@ func GetLocationAreas(paginate string) ([]NamedApiResource, error) {
 /*** GetLocationAreaData ***/
 // Types
 type Encounter struct {
-	MinLevel        int              `json:"min_level"`
-	MaxLevel        int              `json:"max_level"`
-	ConditionValues NamedApiResource `json:"conditionValues"`
-	Change          int              `json:"change"`
-	Method          NamedApiResource `json:"method"`
+	MinLevel        int                `json:"min_level"`
+	MaxLevel        int                `json:"max_level"`
+	ConditionValues []NamedApiResource `json:"condition_values"`
+	Chance          int                `json:"chance"`
+	Method          NamedApiResource   `json:"method"`
 }
 
 type VersionEncounterDetail struct {
@@ -104,14 +82,19 @@ type VersionEncounterDetail struct {
 	EncounterDetails []Encounter      `json:"encounter_details"`
 }
 
+type EncounterVersionDetails struct {
+	Rate    int              `json:"rate"`
+	Version NamedApiResource `json:"version"`
+}
+
 type EncounterMethodRate struct {
-	EncounterMethod NamedApiResource         `json:"encounter_method"`
-	VersionDetails  []VersionEncounterDetail `json:"version_details"`
+	EncounterMethod NamedApiResource          `json:"encounter_method"`
+	VersionDetails  []EncounterVersionDetails `json:"version_details"`
 }
 
 type Name struct {
-	Name     string           `json:"Name"`
-	Language NamedApiResource `json:"Language"`
+	Name     string           `json:"name"`
+	Language NamedApiResource `json:"language"`
 }
 
 type PokemonEncounter struct {
@@ -129,22 +112,8 @@ type LocationArea struct {
 	PokemonEncounters    []PokemonEncounter    `json:"pokemon_encounters"`
 }
 
-func GetLocationAreaData(location string) (LocationArea, error) {
-	url := BASE_URL + "/location-area/" + location
-
-	data, err := cachedGet(url)
-	if err != nil {
-		return LocationArea{}, err
-	}
-
-	var result LocationArea
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return LocationArea{}, errors.New("unable to parse location JSON")
-	}
-
-	return result, nil
-
+func (c *Client) GetLocationAreaData(ctx context.Context, location string) (LocationArea, error) {
+	return c.transport.locationArea(ctx, location)
 }
 
 /*** GetPokemon ***/
@@ -165,20 +134,15 @@ type PokemonHeldItem struct {
 		Version NamedApiResource `json:"version"`
 	} `json:"version_details"`
 }
+type PokemonMoveVersion struct {
+	LevelLearnedAt  int              `json:"level_learned_at"`
+	VersionGroup    NamedApiResource `json:"version_group"`
+	MoveLearnMethod NamedApiResource `json:"move_learn_method"`
+	Order           int              `json:"order"`
+}
 type PokemonMove struct {
-	Move                NamedApiResource `json:"move"`
-	VersionGroupDetails []struct {
-		LevelLearnedAt int `json:"level_learned_at"`
-		VersionGroup   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version_group"`
-		MoveLearnMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move_learn_method"`
-		Order int `json:"order"`
-	} `json:"version_group_details"`
+	Move                NamedApiResource     `json:"move"`
+	VersionGroupDetails []PokemonMoveVersion `json:"version_group_details"`
 }
 type PokemonSprites struct {
 	BackDefault      string `json:"back_default"`
@@ -400,19 +364,43 @@ type Pokemon struct {
 	PastAbilities          []PokemonAbilityPast `json:"past_abilities"`
 }
 
-func GetPokemon(pokemon string) (Pokemon, error) {
-	url := BASE_URL + "/pokemon/" + pokemon
+func (c *Client) GetPokemon(ctx context.Context, pokemon string) (Pokemon, error) {
+	return c.transport.pokemon(ctx, pokemon)
+}
 
-	data, err := cachedGet(url)
-	if err != nil {
-		return Pokemon{}, err
-	}
+/*** GetPokemonSummary ***/
+// Types
 
-	var result Pokemon
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return Pokemon{}, errors.New("unable to parse Pokemon JSON")
-	}
+// PokemonSummary is a projection of Pokemon without the sprites, moves and
+// other large lists, for hot paths that only need the basics. Use GetPokemon
+// when the full record is needed.
+type PokemonSummary struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	BaseExperience int              `json:"base_experience"`
+	Height         int              `json:"height"`
+	Weight         int              `json:"weight"`
+	Species        NamedApiResource `json:"species"`
+	Stats          []PokemonStat    `json:"stats"`
+	Types          []PokemonType    `json:"types"`
+}
+
+func (c *Client) GetPokemonSummary(ctx context.Context, pokemon string) (PokemonSummary, error) {
+	return c.transport.pokemonSummary(ctx, pokemon)
+}
 
-	return result, nil
+/*** GetPokemonEncounters ***/
+// Types
+type LocationAreaEncounter struct {
+	LocationArea   NamedApiResource         `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+func (c *Client) GetPokemonEncounters(ctx context.Context, pokemon string) ([]LocationAreaEncounter, error) {
+	slug := Slug(pokemon)
+	encounters, err := Fetch[[]LocationAreaEncounter](ctx, c, c.baseURL+"/pokemon/"+slug+"/encounters")
+	if errors.Is(err, ErrNotFound) {
+		return nil, notFound(ctx, c, "pokemon", slug)
+	}
+	return encounters, err
 }
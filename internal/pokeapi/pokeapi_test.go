@@ -0,0 +1,640 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// loadFixture reads a recorded PokeAPI response from testdata and returns
+// both its raw bytes and its generic JSON form.
+func loadFixture(t *testing.T, path string) ([]byte, any) {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + path)
+	if err != nil {
+		t.Fatalf("unable to read fixture: %v", err)
+	}
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unable to parse fixture: %v", err)
+	}
+	return data, raw
+}
+
+// isolateIndex gives a test its own index directory and an empty in-memory
+// index, restoring both when the test ends.
+func isolateIndex(t *testing.T) {
+	t.Helper()
+	indexDir, resources := IndexDir, nameIndex.resources
+	t.Cleanup(func() {
+		IndexDir = indexDir
+		nameIndex.resources = resources
+	})
+	IndexDir = t.TempDir()
+	nameIndex.resources = map[string][]NamedApiResource{}
+}
+
+// assertMatchesFixture re-encodes a decoded value and checks that every field
+// it produces exists in the recorded response with the same value. A field
+// whose tag does not match the API decodes to its zero value, so it shows up
+// here as a key the response never had.
+func assertMatchesFixture(t *testing.T, typed any, raw any) {
+	t.Helper()
+	data, err := json.Marshal(typed)
+	if err != nil {
+		t.Fatalf("unable to encode decoded value: %v", err)
+	}
+	var encoded any
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatalf("unable to parse encoded value: %v", err)
+	}
+	for _, problem := range compareJSON("$", encoded, raw) {
+		t.Error(problem)
+	}
+}
+
+func compareJSON(path string, encoded any, raw any) []string {
+	if raw == nil {
+		// null in the response decodes to the zero value on purpose
+		return nil
+	}
+
+	switch encoded := encoded.(type) {
+	case map[string]any:
+		rawMap, ok := raw.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: decoded an object, response has %T", path, raw)}
+		}
+		problems := []string{}
+		for key, value := range encoded {
+			rawValue, ok := rawMap[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: not in the response, always decodes to zero", path, key))
+				continue
+			}
+			problems = append(problems, compareJSON(path+"."+key, value, rawValue)...)
+		}
+		return problems
+	case []any:
+		rawSlice, ok := raw.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: decoded an array, response has %T", path, raw)}
+		}
+		if len(encoded) != len(rawSlice) {
+			return []string{fmt.Sprintf("%s: decoded %d items, response has %d", path, len(encoded), len(rawSlice))}
+		}
+		problems := []string{}
+		for i := range encoded {
+			problems = append(problems, compareJSON(fmt.Sprintf("%s[%d]", path, i), encoded[i], rawSlice[i])...)
+		}
+		return problems
+	default:
+		if !reflect.DeepEqual(encoded, raw) {
+			return []string{fmt.Sprintf("%s: decoded %v, response has %v", path, encoded, raw)}
+		}
+		return nil
+	}
+}
+
+func TestLocationAreaEncounterContract(t *testing.T) {
+	data, raw := loadFixture(t, "location-area/sinnoh-route-201-area.json")
+
+	var location LocationArea
+	if err := json.Unmarshal(data, &location); err != nil {
+		t.Fatalf("unable to decode location area: %v", err)
+	}
+
+	rawLocation := raw.(map[string]any)
+	assertMatchesFixture(t, location.PokemonEncounters, rawLocation["pokemon_encounters"])
+	assertMatchesFixture(t, location.EncounterMethodRates, rawLocation["encounter_method_rates"])
+
+	detail := location.PokemonEncounters[1].VersionDetails[0].EncounterDetails[0]
+	if detail.Chance != 10 {
+		t.Errorf("expected chance 10, got %v", detail.Chance)
+	}
+	if len(detail.ConditionValues) != 1 || detail.ConditionValues[0].Name != "time-morning" {
+		t.Errorf("expected time-morning condition, got %v", detail.ConditionValues)
+	}
+}
+
+func TestPokemonEncountersContract(t *testing.T) {
+	data, raw := loadFixture(t, "pokemon/starly-encounters.json")
+
+	var encounters []LocationAreaEncounter
+	if err := json.Unmarshal(data, &encounters); err != nil {
+		t.Fatalf("unable to decode pokemon encounters: %v", err)
+	}
+
+	assertMatchesFixture(t, encounters, raw)
+}
+
+func TestLocationAreaContract(t *testing.T) {
+	data, raw := loadFixture(t, "location-area/sinnoh-route-201-area.json")
+
+	var location LocationArea
+	if err := json.Unmarshal(data, &location); err != nil {
+		t.Fatalf("unable to decode location area: %v", err)
+	}
+
+	assertMatchesFixture(t, location, raw)
+}
+
+func TestLocalizedName(t *testing.T) {
+	data, _ := loadFixture(t, "location-area/sinnoh-route-201-area.json")
+
+	var location LocationArea
+	if err := json.Unmarshal(data, &location); err != nil {
+		t.Fatalf("unable to decode location area: %v", err)
+	}
+
+	cases := []struct {
+		language string
+		expected string
+	}{
+		{language: "ja", expected: "201ばんどうろ"},
+		{language: "en", expected: "Route 201"},
+		{language: "fr", expected: "Route 201"},
+	}
+	for _, c := range cases {
+		actual := LocalizedName(location.Names, c.language, location.Name)
+		if actual != c.expected {
+			t.Errorf("%v: expected %v, got %v", c.language, c.expected, actual)
+		}
+	}
+
+	if actual := LocalizedName(nil, "ja", location.Name); actual != location.Name {
+		t.Errorf("expected fallback %v, got %v", location.Name, actual)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	retryBackoff = time.Millisecond
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/flaky/":
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"name": "stat", "url": "https://pokeapi.co/api/v2/stat/1/"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "Not Found")
+		}
+	}))
+	defer server.Close()
+	client := NewClient()
+
+	resource, err := Fetch[NamedApiResource](context.Background(), client, server.URL+"/flaky/")
+	if err != nil {
+		t.Fatalf("expected a retry to succeed, got %v", err)
+	}
+	if resource.Name != "stat" || resource.ID() != 1 {
+		t.Errorf("unexpected resource %v", resource)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %v", requests)
+	}
+
+	if _, err := Fetch[NamedApiResource](context.Background(), client, server.URL+"/flaky/"); err != nil {
+		t.Errorf("expected a cached response, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the cache to answer, got %v requests", requests)
+	}
+
+	_, err = Resolve[NamedApiResource](context.Background(), client, NamedApiResource{Name: "missing", Url: server.URL + "/missing/"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected no retries for a missing resource, got %v requests", requests)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "pikachu", expected: "pikachu"},
+		{input: "Mr. Mime", expected: "mr-mime"},
+		{input: "Mime Jr.", expected: "mime-jr"},
+		{input: "Farfetch'd", expected: "farfetchd"},
+		{input: "Flabébé", expected: "flabebe"},
+		{input: "Nidoran♀", expected: "nidoran-f"},
+		{input: "Type: Null", expected: "type-null"},
+		{input: "  canalave city area ", expected: "canalave-city-area"},
+		{input: "025", expected: "25"},
+	}
+	for _, c := range cases {
+		if actual := Slug(c.input); actual != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.input, c.expected, actual)
+		}
+	}
+}
+
+func TestGetNamedSuggestions(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/pokemon/":
+			fmt.Fprint(w, `{"count": 3, "results": [
+				{"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon/25/"},
+				{"name": "pichu", "url": "https://pokeapi.co/api/v2/pokemon/172/"},
+				{"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon/1/"}
+			]}`)
+		case "/pokemon/25":
+			fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	isolateIndex(t)
+	client := NewClient(WithBaseURL(server.URL))
+
+	pokemon, err := client.GetPokemon(context.Background(), "025")
+	if err != nil || pokemon.Name != "pikachu" {
+		t.Errorf("expected pikachu by id, got %v, %v", pokemon.Name, err)
+	}
+
+	_, err = client.GetPokemon(context.Background(), "Pikachoo")
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+	if len(notFoundErr.Suggestions) == 0 || notFoundErr.Suggestions[0] != "pikachu" {
+		t.Errorf("expected pikachu to be suggested, got %v", notFoundErr.Suggestions)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the error to be ErrNotFound")
+	}
+
+	requests = 0
+	_, err = client.GetPokemon(context.Background(), "Pichoo")
+	if !errors.As(err, &notFoundErr) || len(notFoundErr.Suggestions) == 0 || notFoundErr.Suggestions[0] != "pichu" {
+		t.Errorf("expected pichu to be suggested, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected the index to answer without a request, got %v requests", requests)
+	}
+	if entries, _ := os.ReadDir(IndexDir); len(entries) != 0 {
+		t.Errorf("expected only sync to save an index, got %v", entries)
+	}
+}
+
+func TestGetNamedMixedCase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/language/":
+			fmt.Fprint(w, `{"count": 2, "results": [
+				{"name": "ja-Hrkt", "url": "https://pokeapi.co/api/v2/language/1/"},
+				{"name": "fr", "url": "https://pokeapi.co/api/v2/language/5/"}
+			]}`)
+		case "/language/ja-Hrkt":
+			fmt.Fprint(w, `{"id": 1, "name": "ja-Hrkt"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	isolateIndex(t)
+	client := NewClient(WithBaseURL(server.URL))
+
+	// once by asking PokeAPI, then again from the index it fetched
+	for range 2 {
+		language, err := client.GetLanguage(context.Background(), "ja-Hrkt")
+		if err != nil || language.Name != "ja-Hrkt" {
+			t.Errorf("expected ja-Hrkt, got %v, %v", language.Name, err)
+		}
+	}
+}
+
+func TestSyncIndexResumes(t *testing.T) {
+	names := []string{}
+	for i := 1; i <= syncPageSize+50; i++ {
+		names = append(names, fmt.Sprintf("pokemon-%d", i))
+	}
+	failAt := syncPageSize
+	offsets := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := 0
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		if offset == failAt {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		list := NamedApiResourceList{Count: len(names)}
+		for i := offset; i < len(names) && i < offset+syncPageSize; i++ {
+			list.Results = append(list.Results, NamedApiResource{Name: names[i], Url: fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d/", i+1)})
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+	isolateIndex(t)
+	client := NewClient(WithBaseURL(server.URL))
+
+	progress := func(done int, total int) {}
+	if err := client.SyncIndex(context.Background(), "pokemon", progress); err == nil {
+		t.Fatalf("expected the interrupted sync to fail")
+	}
+	if _, ok := client.CachedIndex("pokemon"); ok {
+		t.Errorf("expected a partial index not to be used")
+	}
+
+	failAt = -1
+	if err := client.SyncIndex(context.Background(), "pokemon", progress); err != nil {
+		t.Fatalf("expected the sync to resume, got %v", err)
+	}
+	expected := []string{"0", "200", "200"}
+	if !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("expected requests at offsets %v, got %v", expected, offsets)
+	}
+
+	index, ok := client.CachedIndex("pokemon")
+	if !ok || len(index) != len(names) {
+		t.Fatalf("expected %d synced names, got %d", len(names), len(index))
+	}
+	if index[len(index)-1].ID() != len(names) {
+		t.Errorf("expected the last id to be %d, got %d", len(names), index[len(index)-1].ID())
+	}
+
+	// An index older than IndexMaxAge is synced again from the start.
+	stored, _ := client.loadIndex("pokemon")
+	stored.SyncedAt = time.Now().Add(-IndexMaxAge - time.Hour)
+	if err := client.saveIndex("pokemon", stored); err != nil {
+		t.Fatal(err)
+	}
+	nameIndex.resources = map[string][]NamedApiResource{}
+	if _, ok := client.CachedIndex("pokemon"); ok {
+		t.Errorf("expected an expired index not to be used")
+	}
+	if err := client.SyncIndex(context.Background(), "pokemon", progress); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := client.loadIndex("pokemon"); stored.expired() || len(stored.Results) != len(names) {
+		t.Errorf("expected a fresh index of %d names, got %d synced at %v", len(names), len(stored.Results), stored.SyncedAt)
+	}
+	if _, ok := client.CachedIndex("pokemon"); !ok {
+		t.Errorf("expected the new index to be used")
+	}
+
+	// An index synced from one backend is never used for another.
+	other := NewClient(WithBaseURL(server.URL + "/mirror"))
+	if _, ok := other.CachedIndex("pokemon"); ok {
+		t.Errorf("expected another backend not to use the synced index")
+	}
+	nameIndex.resources = map[string][]NamedApiResource{}
+	if _, ok := other.CachedIndex("pokemon"); ok {
+		t.Errorf("expected another backend not to load the stored index")
+	}
+}
+
+func TestPokemonContract(t *testing.T) {
+	data, raw := loadFixture(t, "pokemon/pikachu.json")
+
+	var pokemon Pokemon
+	if err := json.Unmarshal(data, &pokemon); err != nil {
+		t.Fatalf("unable to decode pokemon: %v", err)
+	}
+	assertMatchesFixture(t, pokemon, raw)
+
+	var summary PokemonSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("unable to decode pokemon summary: %v", err)
+	}
+	assertMatchesFixture(t, summary, raw)
+}
+
+func BenchmarkDecodePokemon(b *testing.B) {
+	data, err := os.ReadFile("testdata/pokemon/pikachu.json")
+	if err != nil {
+		b.Fatalf("unable to read fixture: %v", err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var pokemon Pokemon
+		if err := json.Unmarshal(data, &pokemon); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePokemonSummary(b *testing.B) {
+	data, err := os.ReadFile("testdata/pokemon/pikachu.json")
+	if err != nil {
+		b.Fatalf("unable to read fixture: %v", err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var summary PokemonSummary
+		if err := json.Unmarshal(data, &summary); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// roundTripperFunc lets a function stand in for an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestFetchRequestErrors(t *testing.T) {
+	retryBackoff = time.Millisecond
+	attempts := 0
+	client := NewClient(WithRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return http.DefaultTransport.RoundTrip(req)
+	})))
+
+	if _, err := client.GetLocationAreas(context.Background(), "prev"); err == nil || !strings.Contains(err.Error(), "use `map` first") {
+		t.Errorf("expected paging back before map to fail, got %v", err)
+	}
+	if attempts != 0 {
+		t.Errorf("expected no requests, got %v", attempts)
+	}
+
+	if _, err := Fetch[NamedApiResource](context.Background(), client, "pokeapi.co/api/v2/stat/1/"); err == nil {
+		t.Errorf("expected a URL without a scheme to fail")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a bad URL not to be retried, got %v attempts", attempts)
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	attempts = 0
+	if _, err := Fetch[NamedApiResource](context.Background(), client, server.URL+"/stat/1/"); err == nil {
+		t.Errorf("expected a closed server to fail")
+	}
+	if attempts != maxAttempts {
+		t.Errorf("expected a refused connection to be retried, got %v attempts", attempts)
+	}
+}
+
+func TestFetchBodyLimit(t *testing.T) {
+	data, err := os.ReadFile("testdata/pokemon/pikachu.json")
+	if err != nil {
+		t.Fatalf("unable to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sized/" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+	defer func(limit int64) { MaxBodySize = limit }(MaxBodySize)
+	client := NewClient()
+
+	MaxBodySize = int64(len(data))
+	pokemon, err := Fetch[Pokemon](context.Background(), client, server.URL+"/streamed/")
+	if err != nil || pokemon.Name != "pikachu" {
+		t.Fatalf("expected a body at the limit to decode, got %v, %v", pokemon.Name, err)
+	}
+	if _, ok := apiCache.Get(server.URL + "/streamed/"); !ok {
+		t.Errorf("expected the streamed body to be cached")
+	}
+
+	MaxBodySize = 1024
+	for _, path := range []string{"/sized/", "/chunked/"} {
+		pokemon, err := Fetch[Pokemon](context.Background(), client, server.URL+path)
+		if !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("%v: expected ErrBodyTooLarge, got %v", path, err)
+		}
+		if !reflect.DeepEqual(pokemon, Pokemon{}) {
+			t.Errorf("%v: expected no pokemon, got %v", path, pokemon.Name)
+		}
+	}
+}
+
+func TestFetchMismatchedType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu", "height": "short"}`)
+	}))
+	defer server.Close()
+	client := NewClient()
+
+	pokemon, err := Fetch[Pokemon](context.Background(), client, server.URL+"/mismatched/")
+	var parseErr *parseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if !reflect.DeepEqual(pokemon, Pokemon{}) {
+		t.Errorf("expected no pokemon, got %v", pokemon.Name)
+	}
+}
+
+func TestGetLocations(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		name := strings.TrimPrefix(r.URL.Path, "/location/")
+		if name == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"name": %q, "areas": [{"name": "%s-area"}]}`, name, name)
+	}))
+	defer server.Close()
+	isolateIndex(t)
+	client := NewClient(WithBaseURL(server.URL))
+
+	refs := []NamedApiResource{}
+	for i := 1; i <= 20; i++ {
+		refs = append(refs, NamedApiResource{Name: fmt.Sprintf("location-%d", i)})
+	}
+	locations, err := client.GetLocations(context.Background(), refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, location := range locations {
+		if location.Name != refs[i].Name || location.Areas[0].Name != refs[i].Name+"-area" {
+			t.Errorf("expected %v in position %v, got %v", refs[i].Name, i, location.Name)
+		}
+	}
+	if peak < 2 || peak > maxConcurrentRequests {
+		t.Errorf("expected between 2 and %v requests at once, got %v", maxConcurrentRequests, peak)
+	}
+
+	refs = append(refs, NamedApiResource{Name: "missing"})
+	if _, err := client.GetLocations(context.Background(), refs); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGrowthRate(t *testing.T) {
+	// the medium growth rate: level n takes n³ experience, and level 1 none
+	rate := GrowthRate{Name: "medium"}
+	for level := 100; level >= 1; level-- {
+		rate.Levels = append(rate.Levels, GrowthRateExperienceLevel{Level: level, Experience: level * level * level})
+	}
+	rate.Levels[len(rate.Levels)-1].Experience = 0
+
+	levels := []struct {
+		level      int
+		experience int
+	}{
+		{level: 1, experience: 0},
+		{level: 2, experience: 8},
+		{level: 50, experience: 125000},
+		{level: 100, experience: 1000000},
+		{level: 0, experience: -1},
+		{level: 101, experience: -1},
+	}
+	for _, tc := range levels {
+		if actual := rate.ExperienceForLevel(tc.level); actual != tc.experience {
+			t.Errorf("level %v: expected %v experience, got %v", tc.level, tc.experience, actual)
+		}
+	}
+
+	experiences := []struct {
+		experience int
+		level      int
+	}{
+		{experience: 0, level: 1},
+		{experience: 7, level: 1},
+		{experience: 8, level: 2},
+		{experience: 124999, level: 49},
+		{experience: 125000, level: 50},
+		{experience: 999999, level: 99},
+		{experience: 1000000, level: 100},
+		{experience: 5000000, level: 100},
+		{experience: -1, level: 0},
+	}
+	for _, tc := range experiences {
+		if actual := rate.LevelForExperience(tc.experience); actual != tc.level {
+			t.Errorf("%v experience: expected level %v, got %v", tc.experience, tc.level, actual)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package pokeapi
+
+import (
+	"context"
+)
+
+/*** GetPokemonSpecies ***/
+// Types
+type FlavorText struct {
+	FlavorText string           `json:"flavor_text"`
+	Language   NamedApiResource `json:"language"`
+	Version    NamedApiResource `json:"version"`
+}
+
+type Genus struct {
+	Genus    string           `json:"genus"`
+	Language NamedApiResource `json:"language"`
+}
+
+type PokemonSpeciesDexEntry struct {
+	EntryNumber int              `json:"entry_number"`
+	Pokedex     NamedApiResource `json:"pokedex"`
+}
+
+type PokemonSpeciesVariety struct {
+	IsDefault bool             `json:"is_default"`
+	Pokemon   NamedApiResource `json:"pokemon"`
+}
+
+type PokemonSpecies struct {
+	ID                   int                      `json:"id"`
+	Name                 string                   `json:"name"`
+	Order                int                      `json:"order"`
+	GenderRate           int                      `json:"gender_rate"`
+	CaptureRate          int                      `json:"capture_rate"`
+	BaseHappiness        int                      `json:"base_happiness"`
+	IsBaby               bool                     `json:"is_baby"`
+	IsLegendary          bool                     `json:"is_legendary"`
+	IsMythical           bool                     `json:"is_mythical"`
+	HatchCounter         int                      `json:"hatch_counter"`
+	HasGenderDifferences bool                     `json:"has_gender_differences"`
+	FormsSwitchable      bool                     `json:"forms_switchable"`
+	GrowthRate           NamedApiResource         `json:"growth_rate"`
+	PokedexNumbers       []PokemonSpeciesDexEntry `json:"pokedex_numbers"`
+	EggGroups            []NamedApiResource       `json:"egg_groups"`
+	Color                NamedApiResource         `json:"color"`
+	Shape                NamedApiResource         `json:"shape"`
+	EvolvesFromSpecies   NamedApiResource         `json:"evolves_from_species"`
+	EvolutionChain       ApiResource              `json:"evolution_chain"`
+	Habitat              NamedApiResource         `json:"habitat"`
+	Generation           NamedApiResource         `json:"generation"`
+	Names                []Name                   `json:"names"`
+	FlavorTextEntries    []FlavorText             `json:"flavor_text_entries"`
+	Genera               []Genus                  `json:"genera"`
+	Varieties            []PokemonSpeciesVariety  `json:"varieties"`
+}
+
+func (c *Client) GetPokemonSpecies(ctx context.Context, species string) (PokemonSpecies, error) {
+	return getNamed[PokemonSpecies](ctx, c, "pokemon-species", species)
+}
@@ -0,0 +1,98 @@
+package pokeapi
+
+import (
+	"context"
+)
+
+/*** GetNature ***/
+// Types
+type NatureStatAffect struct {
+	MaxChange      int              `json:"max_change"`
+	PokeathlonStat NamedApiResource `json:"pokeathlon_stat"`
+}
+
+type MoveBattleStylePreference struct {
+	LowHPPreference  int              `json:"low_hp_preference"`
+	HighHPPreference int              `json:"high_hp_preference"`
+	MoveBattleStyle  NamedApiResource `json:"move_battle_style"`
+}
+
+type Nature struct {
+	ID                         int                         `json:"id"`
+	Name                       string                      `json:"name"`
+	DecreasedStat              NamedApiResource            `json:"decreased_stat"`
+	IncreasedStat              NamedApiResource            `json:"increased_stat"`
+	HatesFlavor                NamedApiResource            `json:"hates_flavor"`
+	LikesFlavor                NamedApiResource            `json:"likes_flavor"`
+	PokeathlonStatChanges      []NatureStatAffect          `json:"pokeathlon_stat_changes"`
+	MoveBattleStylePreferences []MoveBattleStylePreference `json:"move_battle_style_preferences"`
+	Names                      []Name                      `json:"names"`
+}
+
+func (c *Client) GetNature(ctx context.Context, nature string) (Nature, error) {
+	return getNamed[Nature](ctx, c, "nature", nature)
+}
+
+/*** GetCharacteristic ***/
+// Types
+type Description struct {
+	Description string           `json:"description"`
+	Language    NamedApiResource `json:"language"`
+}
+
+type Characteristic struct {
+	ID             int              `json:"id"`
+	GeneModulo     int              `json:"gene_modulo"`
+	PossibleValues []int            `json:"possible_values"`
+	HighestStat    NamedApiResource `json:"highest_stat"`
+	Descriptions   []Description    `json:"descriptions"`
+}
+
+// GetCharacteristic looks up a characteristic by id; characteristics have no
+// names.
+func (c *Client) GetCharacteristic(ctx context.Context, characteristic string) (Characteristic, error) {
+	return getNamed[Characteristic](ctx, c, "characteristic", characteristic)
+}
+
+/*** GetGrowthRate ***/
+// Types
+type GrowthRateExperienceLevel struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+}
+
+type GrowthRate struct {
+	ID             int                         `json:"id"`
+	Name           string                      `json:"name"`
+	Formula        string                      `json:"formula"`
+	Descriptions   []Description               `json:"descriptions"`
+	Levels         []GrowthRateExperienceLevel `json:"levels"`
+	PokemonSpecies []NamedApiResource          `json:"pokemon_species"`
+}
+
+// ExperienceForLevel returns the total experience needed to reach a level, or
+// -1 if the level is not in the table.
+func (rate GrowthRate) ExperienceForLevel(level int) int {
+	for _, entry := range rate.Levels {
+		if entry.Level == level {
+			return entry.Experience
+		}
+	}
+	return -1
+}
+
+// LevelForExperience returns the highest level reached with the given total
+// experience.
+func (rate GrowthRate) LevelForExperience(experience int) int {
+	level := 0
+	for _, entry := range rate.Levels {
+		if entry.Experience <= experience && entry.Level > level {
+			level = entry.Level
+		}
+	}
+	return level
+}
+
+func (c *Client) GetGrowthRate(ctx context.Context, growthRate string) (GrowthRate, error) {
+	return getNamed[GrowthRate](ctx, c, "growth-rate", growthRate)
+}
@@ -0,0 +1,35 @@
+package pokeapi
+
+import (
+	"context"
+)
+
+/*** GetVersion ***/
+// Types
+type Version struct {
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Names        []Name           `json:"names"`
+	VersionGroup NamedApiResource `json:"version_group"`
+}
+
+func (c *Client) GetVersion(ctx context.Context, version string) (Version, error) {
+	return getNamed[Version](ctx, c, "version", version)
+}
+
+/*** GetVersionGroup ***/
+// Types
+type VersionGroup struct {
+	ID               int                `json:"id"`
+	Name             string             `json:"name"`
+	Order            int                `json:"order"`
+	Generation       NamedApiResource   `json:"generation"`
+	MoveLearnMethods []NamedApiResource `json:"move_learn_methods"`
+	Pokedexes        []NamedApiResource `json:"pokedexes"`
+	Regions          []NamedApiResource `json:"regions"`
+	Versions         []NamedApiResource `json:"versions"`
+}
+
+func (c *Client) GetVersionGroup(ctx context.Context, versionGroup string) (VersionGroup, error) {
+	return getNamed[VersionGroup](ctx, c, "version-group", versionGroup)
+}
@@ -1,41 +1,257 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/logan-waite/bootdev_pokedex/internal/lineedit"
 )
 
 func main() {
-	initPokedex()
+	script := flag.String("script", "", "run the REPL commands in `file` and exit")
+	output := flag.String("output", "text", "show results as `format`: text, json, yaml, csv or table")
+	seed := flag.Uint64("seed", 0, "seed catches with `n`, so a session can be replayed")
+	flag.Usage = usage
+	flag.Parse()
+	initCommands()
 
-	scanner := bufio.NewScanner(os.Stdin)
+	c := &commandContext{session: newSession(), ctx: context.Background(), out: os.Stdout, errOut: os.Stderr}
+	c.outputFormat = strings.ToLower(*output)
+	if err := checkFormat(c.outputFormat); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			c.setSeed(*seed)
+		}
+	})
 
-	for true {
-		fmt.Print("Pokedex > ")
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 
-		if scanner.Scan() {
-			_input := scanner.Text()
-			input := cleanInput(_input)
+	if *script != "" {
+		if flag.NArg() > 0 {
+			fmt.Fprintln(os.Stderr, "--script cannot be combined with a command")
+			os.Exit(2)
+		}
+		go handleSignals(signals, nil)
+		os.Exit(runOnce(c, []string{"source", "--", *script}))
+	}
+	if flag.NArg() > 0 {
+		go handleSignals(signals, nil)
+		os.Exit(runOnce(c, flag.Args()))
+	}
 
-			cmd, arg := "", ""
-			if len(input) > 0 {
-				cmd = input[0]
-			}
-			if len(input) > 1 {
-				arg = input[1]
-			}
+	editor := lineedit.New(os.Stdin, os.Stdout, historyFile())
+	editor.Complete = c.complete
+	go handleSignals(signals, editor)
+
+	code := runREPL(c, editor)
+	shutdown(editor)
+	os.Exit(code)
+}
+
+func usage() {
+	fmt.Fprintln(flag.CommandLine.Output(), `Usage:
+  pokedex                        start the interactive Pokedex
+  pokedex <command> [arguments]  run one command and exit
+  pokedex --script <file>        run the commands in a file and exit
+
+Run "pokedex help" to list the commands.`)
+	flag.PrintDefaults()
+}
+
+// runOnce runs a single command given on the command line, for scripts. It
+// returns 0 on success, 1 if the command failed and 2 if it was not
+// understood.
+func runOnce(c *commandContext, argv []string) int {
+	cmd, args := parseArgs(argv)
+	command, ok := lookupCommand(cmd)
+	if !ok {
+		fmt.Fprintf(c.errOut, "Unknown command %q; run \"pokedex help\" to list the commands\n", cmd)
+		return 2
+	}
+	if err := checkFlags(command, args); err != nil {
+		fmt.Fprintf(c.errOut, "Error when calling %s: %v\n", command.name, err)
+		return 2
+	}
 
-			if cmd == "" {
-				continue
-			} else if command, ok := commands[cmd]; ok {
-				err := command.callback(arg)
-				if err != nil {
-					fmt.Printf("Error when calling %s: %v\n", command.name, err)
-				}
-			} else {
-				fmt.Println("Unknown command")
+	err := runCommand(c, command, args)
+	if err != nil && !errors.Is(err, errExit) {
+		fmt.Fprintf(c.errOut, "Error when calling %s: %v\n", command.name, err)
+		return 1
+	}
+	return 0
+}
+
+// runREPL reads and runs commands until exit or the end of input, and
+// returns the exit code. Only lines typed at a terminal go into history, so
+// a piped script never fills it.
+func runREPL(c *commandContext, editor *lineedit.Editor) int {
+	historyFailed := false
+	for {
+		input, err := editor.ReadLine("Pokedex > ")
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			commandExit(c, cliArgs{})
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(c.errOut, "Unable to read input: %v\n", err)
+			return 1
+		}
+		if editor.Terminal() {
+			if err := editor.AddHistory(input); err != nil && !historyFailed {
+				historyFailed = true
+				fmt.Fprintf(c.errOut, "Unable to save history, keeping it for this session only: %v\n", err)
 			}
 		}
+
+		cmd, args, err := parseInput(input)
+		if err != nil {
+			fmt.Fprintf(c.out, "Unable to read command: %v\n", err)
+			continue
+		}
+		if cmd == "" {
+			continue
+		}
+		command, ok := lookupCommand(cmd)
+		if !ok {
+			fmt.Fprintln(c.out, "Unknown command")
+			continue
+		}
+
+		err = checkFlags(command, args)
+		if err == nil {
+			err = runCommand(c, command, args)
+		}
+		if errors.Is(err, errExit) {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(c.out, "Error when calling %s: %v\n", command.name, err)
+		}
+	}
+}
+
+// The running command's cancel function, for Ctrl-C, and whether Ctrl-C
+// has already cancelled it
+var running struct {
+	sync.Mutex
+	cancel      context.CancelFunc
+	interrupted bool
+}
+
+// runCommand calls a command with a context that Ctrl-C cancels, then
+// renders its result. Commands run by a script share the script's context,
+// so Ctrl-C stops the whole script.
+func runCommand(c *commandContext, command cliCommand, args cliArgs) error {
+	format, err := resultFormat(c.outputFormat, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+
+	running.Lock()
+	outermost := running.cancel == nil
+	if outermost {
+		running.cancel = cancel
+	}
+	running.Unlock()
+	if outermost {
+		defer func() {
+			running.Lock()
+			running.cancel = nil
+			running.interrupted = false
+			running.Unlock()
+		}()
+	}
+
+	child := *c
+	child.ctx = ctx
+	result, err := command.callback(&child, args)
+	if err != nil {
+		return err
+	}
+	return render(c.out, format, result)
+}
+
+// checkFlags rejects flags a command does not take and output formats that
+// do not exist.
+func checkFlags(command cliCommand, args cliArgs) error {
+	for name := range args.flags {
+		if known, _ := command.flagSpec(name); !known {
+			return fmt.Errorf("unknown flag --%s", name)
+		}
+	}
+	if format, ok := args.flag("output"); ok {
+		return checkFormat(strings.ToLower(format))
+	}
+	return nil
+}
+
+// handleSignals cancels the running command on Ctrl-C. SIGTERM, Ctrl-C with
+// no command running, or a second Ctrl-C before the command has stopped
+// shuts the Pokedex down.
+func handleSignals(signals <-chan os.Signal, editor *lineedit.Editor) {
+	for sig := range signals {
+		running.Lock()
+		cancel := running.cancel
+		interrupt := sig == os.Interrupt && cancel != nil && !running.interrupted
+		if interrupt {
+			running.interrupted = true
+		}
+		running.Unlock()
+		if interrupt {
+			cancel()
+			continue
+		}
+
+		fmt.Println()
+		shutdown(editor)
+		code := 130
+		if sig == syscall.SIGTERM {
+			code = 143
+		}
+		os.Exit(code)
+	}
+}
+
+// shutdown restores the terminal and saves the REPL history before the
+// Pokedex exits. There is nothing else to flush: sync saves the name index
+// after every page, and the response cache only lives in memory.
+func shutdown(editor *lineedit.Editor) {
+	if editor == nil {
+		return
+	}
+	if err := editor.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to save history: %v\n", err)
+	}
+}
+
+// historyFile is where REPL history is kept between sessions, unless
+// POKEDEX_HISTORY names another file.
+func historyFile() string {
+	if file := os.Getenv("POKEDEX_HISTORY"); file != "" {
+		return file
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
 	}
+	return filepath.Join(dir, "bootdev_pokedex", "history")
 }
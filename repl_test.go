@@ -1,44 +1,249 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/logan-waite/bootdev_pokedex/internal/lineedit"
+	"github.com/logan-waite/bootdev_pokedex/internal/pokeapi"
 )
 
-func TestCleanInput(t *testing.T) {
+func TestParseInput(t *testing.T) {
 	cases := []struct {
-		input    string
-		expected []string
+		input      string
+		command    string
+		positional []string
+		flags      map[string]string
 	}{
 		{
-			input:    "hello world",
-			expected: []string{"hello", "world"},
+			input:      "hello world",
+			command:    "hello",
+			positional: []string{"world"},
+		},
+		{
+			input:      "YELLING at YOU",
+			command:    "yelling",
+			positional: []string{"at", "YOU"},
+		},
+		{
+			input:      "  lots    of white	 space     ",
+			command:    "lots",
+			positional: []string{"of", "white", "space"},
+		},
+		{
+			input:   "single",
+			command: "single",
+		},
+		{
+			input:      `nickname pikachu "Sparky the Second"`,
+			command:    "nickname",
+			positional: []string{"pikachu", "Sparky the Second"},
+		},
+		{
+			input:      `catch 'farfetch'\''d' "say \"hi\"" mr\ mime`,
+			command:    "catch",
+			positional: []string{"farfetch'd", `say "hi"`, "mr mime"},
 		},
 		{
-			input:    "YELLING at YOU",
-			expected: []string{"yelling", "at", "you"},
+			input:      `explore --json eterna-city-area --region=Sinnoh`,
+			command:    "explore",
+			positional: []string{"eterna-city-area"},
+			flags:      map[string]string{"json": "true", "region": "Sinnoh"},
 		},
 		{
-			input:    "  lots    of white	 space     ",
-			expected: []string{"lots", "of", "white", "space"},
+			input:      `search "--json" -- --resource`,
+			command:    "search",
+			positional: []string{"--json", "--resource"},
 		},
 		{
-			input:    "single",
-			expected: []string{"single"},
+			input:      `nickname pikachu ""`,
+			command:    "nickname",
+			positional: []string{"pikachu", ""},
 		},
 	}
 
 	for _, c := range cases {
-		actual := cleanInput(c.input)
-		if len(actual) == len(c.expected) {
-			for i := range actual {
-				word := actual[i]
-				expectedWord := c.expected[i]
-				if word != expectedWord {
-					t.Errorf("test failed; %v != %v", actual, c.expected)
-				}
+		command, args, err := parseInput(c.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.input, err)
+			continue
+		}
+		if command != c.command || !slices.Equal(args.positional, c.positional) {
+			t.Errorf("%q: expected %v %q, got %v %q", c.input, c.command, c.positional, command, args.positional)
+		}
+		if len(args.flags) != len(c.flags) {
+			t.Errorf("%q: expected flags %v, got %v", c.input, c.flags, args.flags)
+		}
+		for name, value := range c.flags {
+			if actual, ok := args.flag(name); !ok || actual != value {
+				t.Errorf("%q: expected --%v=%v, got %v", c.input, name, value, args.flags)
 			}
-		} else {
-			t.Errorf("test failed; %v != %v", actual, c.expected)
 		}
 	}
+
+	if _, _, err := parseInput(`nickname pikachu "Sparky`); err == nil {
+		t.Errorf("expected an error for an unclosed quote")
+	}
+}
+
+// newTestContext returns a context for a fresh session whose output is kept
+// in the returned buffer.
+func newTestContext(t *testing.T, options ...pokeapi.Option) (*commandContext, *bytes.Buffer) {
+	t.Helper()
+	initCommands()
+	indexDir := pokeapi.IndexDir
+	t.Cleanup(func() { pokeapi.IndexDir = indexDir })
+	pokeapi.IndexDir = t.TempDir()
+
+	// The client, language and seed are set here so the environment cannot
+	// change what the tests see.
+	s := newSession()
+	s.client = pokeapi.NewClient(options...)
+	s.language = pokeapi.DefaultLanguage
+	s.setSeed(1)
+	out := &bytes.Buffer{}
+	return &commandContext{session: s, ctx: context.Background(), out: out, errOut: io.Discard}, out
+}
+
+func TestCompleteInput(t *testing.T) {
+	c, _ := newTestContext(t)
+	s := c.session
+	s.pokemonList["starly"] = pokeapi.Pokemon{Name: "starly"}
+	s.listedAreas["sinnoh-route-201-area"] = true
+	s.exploredArea = pokeapi.LocationArea{PokemonEncounters: []pokeapi.PokemonEncounter{
+		{Pokemon: pokeapi.NamedApiResource{Name: "bidoof"}},
+		{Pokemon: pokeapi.NamedApiResource{Name: "kricketot"}},
+	}}
+
+	cases := []struct {
+		head     string
+		expected []string
+	}{
+		{head: "explore ", expected: []string{"sinnoh-route-201-area"}},
+		{head: "CATCH ", expected: []string{"bidoof", "kricketot"}},
+		{head: "inspect ", expected: []string{"starly"}},
+		{head: "inspect starly ", expected: []string{}},
+		{head: "pokedex ", expected: []string{}},
+	}
+	for _, tc := range cases {
+		actual := s.complete(tc.head)
+		sort.Strings(actual)
+		if strings.Join(actual, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("%q: expected %v, got %v", tc.head, tc.expected, actual)
+		}
+	}
+
+	if names := s.complete(""); len(names) != len(commands) || !slices.Contains(names, "explore") {
+		t.Errorf("expected command names, got %v", names)
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	c, _ := newTestContext(t)
+
+	cases := []struct {
+		argv     []string
+		expected int
+	}{
+		{argv: []string{"pokedex"}, expected: 0},
+		{argv: []string{"inspect", "Pikachu"}, expected: 1},
+		{argv: []string{"pokedex", "--colour"}, expected: 2},
+		{argv: []string{"pokedex", "--output", "xml"}, expected: 2},
+		{argv: []string{"pokedex", "--output", "csv"}, expected: 0},
+		{argv: []string{"teleport"}, expected: 2},
+	}
+	for _, tc := range cases {
+		if actual := runOnce(c, tc.argv); actual != tc.expected {
+			t.Errorf("%v: expected exit code %v, got %v", tc.argv, tc.expected, actual)
+		}
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	c, _ := newTestContext(t)
+	dir := t.TempDir()
+	write := func(name string, lines ...string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cases := []struct {
+		name     string
+		script   []string
+		expected string
+	}{
+		{
+			name:     "comments and blank lines",
+			script:   []string{"# list what we have", "", "pokedex", "  # indented comment"},
+			expected: "",
+		},
+		{
+			name:     "keeps going after errors",
+			script:   []string{"inspect pikachu", "teleport", "pokedex"},
+			expected: "2 of the commands",
+		},
+		{
+			name:     "set -e stops at the first error",
+			script:   []string{"pokedex", "set -e", "inspect pikachu", "teleport"},
+			expected: "script.txt:3",
+		},
+		{
+			name:     "set +e",
+			script:   []string{"set -e", "set +e", "inspect pikachu", "pokedex"},
+			expected: "1 of the commands",
+		},
+	}
+	for _, tc := range cases {
+		err := runScript(c, write("script.txt", tc.script...))
+		if tc.expected == "" && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+		if tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)) {
+			t.Errorf("%v: expected an error containing %q, got %v", tc.name, tc.expected, err)
+		}
+	}
+
+	if err := runScript(c, write("exit.txt", "exit", "teleport")); !errors.Is(err, errExit) {
+		t.Errorf("expected exit to end the script, got %v", err)
+	}
+	if err := runScript(c, write("loop.txt", "source "+filepath.Join(dir, "loop.txt"))); err == nil {
+		t.Errorf("expected a script sourcing itself to fail")
+	}
+}
+
+func TestPipedInputHistory(t *testing.T) {
+	c, out := newTestContext(t)
+	dir := t.TempDir()
+	input := filepath.Join(dir, "demo.txt")
+	if err := os.WriteFile(input, []byte("pokedex\nhelp\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	history := filepath.Join(dir, "history")
+	editor := lineedit.New(f, io.Discard, history)
+	if code := runREPL(c, editor); code != 0 {
+		t.Fatalf("expected exit code 0, got %v", code)
+	}
+	if !strings.Contains(out.String(), "Goodbye") {
+		t.Errorf("expected the piped commands to run, got %q", out.String())
+	}
+	if _, err := os.Stat(history); !os.IsNotExist(err) {
+		t.Errorf("expected piped input to stay out of history, got %v", err)
+	}
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// maxScriptDepth stops scripts that source each other from recursing
+// forever.
+const maxScriptDepth = 8
+
+// runScript runs the REPL commands in a file line by line, echoing each one
+// after the prompt. Blank lines and lines starting with # are skipped.
+// `set -e` makes the first failing command stop the script and `set +e`
+// turns that off again. Otherwise the script runs to the end and reports
+// how many commands failed.
+func runScript(c *commandContext, path string) error {
+	if c.scriptDepth >= maxScriptDepth {
+		return fmt.Errorf("scripts nested more than %v deep", maxScriptDepth)
+	}
+	c.scriptDepth++
+	defer func() { c.scriptDepth-- }()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stopOnError := false
+	failed := 0
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if err := c.ctx.Err(); err != nil {
+			return err
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fmt.Fprintf(c.out, "Pokedex > %s\n", line)
+
+		err := runScriptLine(c, line, &stopOnError)
+		if errors.Is(err, errExit) {
+			return err
+		}
+		if err != nil {
+			failed++
+			if stopOnError {
+				return fmt.Errorf("stopped at %s:%v", path, lineNumber)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%v of the commands in %s failed", failed, path)
+	}
+	return nil
+}
+
+// runScriptLine runs one line of a script, handling the `set` options that
+// only scripts understand. Failures are reported the way the REPL reports
+// them.
+func runScriptLine(c *commandContext, line string, stopOnError *bool) error {
+	cmd, args, err := parseInput(line)
+	if err != nil {
+		fmt.Fprintf(c.out, "Unable to read command: %v\n", err)
+		return err
+	}
+	if cmd == "set" {
+		switch args.name() {
+		case "-e":
+			*stopOnError = true
+		case "+e":
+			*stopOnError = false
+		default:
+			fmt.Fprintf(c.out, "Unknown option: set %v\n", args.name())
+			return fmt.Errorf("unknown option %v", args.name())
+		}
+		return nil
+	}
+
+	command, ok := lookupCommand(cmd)
+	if !ok {
+		fmt.Fprintln(c.out, "Unknown command")
+		return fmt.Errorf("unknown command %v", cmd)
+	}
+	err = checkFlags(command, args)
+	if err == nil {
+		err = runCommand(c, command, args)
+	}
+	if err != nil && !errors.Is(err, errExit) {
+		fmt.Fprintf(c.out, "Error when calling %s: %v\n", command.name, err)
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+
+	"github.com/logan-waite/bootdev_pokedex/internal/pokeapi"
+)
+
+// session is the state a Pokedex session keeps from one command to the
+// next.
+type session struct {
+	client      *pokeapi.Client
+	pokemonList map[string]pokeapi.Pokemon
+	language    string
+	game        activeGame
+
+	// Region filter for map and mapb
+	mapRegion string
+
+	// Location areas listed by map, mapb and region, and the last area
+	// explored, for tab completion
+	listedAreas  map[string]bool
+	exploredArea pokeapi.LocationArea
+
+	outputFormat string
+	scriptDepth  int
+
+	// Random source for catches. The seed is kept so a session can be
+	// replayed with --seed or the seed command.
+	seed uint64
+	rng  *rand.Rand
+}
+
+// newSession starts a session set up from the environment.
+func newSession() *session {
+	s := &session{
+		client:       newClient(),
+		pokemonList:  map[string]pokeapi.Pokemon{},
+		language:     pokeapi.DefaultLanguage,
+		listedAreas:  map[string]bool{},
+		outputFormat: "text",
+	}
+	if lang := os.Getenv("POKEDEX_LANG"); lang != "" {
+		s.language = lang
+	}
+	s.setSeed(rand.Uint64())
+	return s
+}
+
+// setSeed restarts the session's random source from seed.
+func (s *session) setSeed(seed uint64) {
+	s.seed = seed
+	s.rng = rand.New(rand.NewPCG(seed, 0))
+}
+
+func newClient() *pokeapi.Client {
+	options := []pokeapi.Option{}
+	if dir := os.Getenv("POKEDEX_DATA_DIR"); dir != "" {
+		options = append(options, pokeapi.WithDirectory(dir))
+	} else if url := os.Getenv("POKEDEX_BASE_URL"); url != "" {
+		options = append(options, pokeapi.WithBaseURL(url))
+	}
+	if os.Getenv("POKEDEX_BACKEND") == "graphql" {
+		endpoint := pokeapi.DefaultGraphQLURL
+		if url := os.Getenv("POKEDEX_GRAPHQL_URL"); url != "" {
+			endpoint = url
+		}
+		options = append(options, pokeapi.WithGraphQL(endpoint))
+	}
+	if userAgent := os.Getenv("POKEDEX_USER_AGENT"); userAgent != "" {
+		options = append(options, pokeapi.WithUserAgent(userAgent))
+	}
+	if os.Getenv("POKEDEX_HTTP_LOG") != "" {
+		logger := log.New(os.Stderr, "pokeapi: ", log.LstdFlags)
+		options = append(options, pokeapi.WithRoundTripper(pokeapi.LoggingTransport{Logger: logger}))
+	}
+	return pokeapi.NewClient(options...)
+}
+
+// commandContext is what a command runs with: the session it acts on, a
+// context that Ctrl-C cancels, and where its output goes.
+type commandContext struct {
+	*session
+	ctx    context.Context
+	out    io.Writer
+	errOut io.Writer
+}
+
+// Active Game
+type activeGame struct {
+	version      pokeapi.Version
+	versionGroup pokeapi.VersionGroup
+}
+
+// includesVersion reports whether data for a version applies to the active
+// game. With no active game every version applies.
+func (g activeGame) includesVersion(version string) bool {
+	if g.version.Name != "" {
+		return g.version.Name == version
+	}
+	if g.versionGroup.Name == "" {
+		return true
+	}
+	for _, v := range g.versionGroup.Versions {
+		if v.Name == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (g activeGame) String() string {
+	if g.version.Name != "" {
+		return fmt.Sprintf("%s (%s)", g.version.Name, g.versionGroup.Name)
+	}
+	if g.versionGroup.Name != "" {
+		return g.versionGroup.Name
+	}
+	return "all games"
+}
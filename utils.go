@@ -1,17 +1,178 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
-func cleanInput(str string) []string {
-	result := []string{}
-	list := strings.Fields(str)
+// cliArgs are the arguments a command was called with. Positional arguments
+// keep their case; flags are written --name or --name=value.
+type cliArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// name reads the positional arguments as one name, so `catch mr mime` works
+// as well as `catch "mr. mime"`.
+func (a cliArgs) name() string {
+	return strings.Join(a.positional, " ")
+}
+
+// flag returns a flag's value, which is "true" for a flag given without one.
+func (a cliArgs) flag(name string) (string, bool) {
+	value, ok := a.flags[name]
+	return value, ok
+}
+
+// enabled reports whether a switch such as --json is on. Giving it without a
+// value turns it on, as does any value strconv.ParseBool reads as true.
+func (a cliArgs) enabled(name string) bool {
+	value, ok := a.flags[name]
+	if !ok {
+		return false
+	}
+	on, err := strconv.ParseBool(value)
+	return err == nil && on
+}
 
-	for _, item := range list {
-		item = strings.ToLower(item)
-		result = append(result, item)
+// parseInput splits a line of input into a command name and its arguments.
+// Words are separated by spaces unless quoted with ' or ", and a backslash
+// escapes the next character. Only the command name is lowercased. A bare
+// -- ends the flags, so later words starting with -- are positional.
+func parseInput(str string) (string, cliArgs, error) {
+	words, err := splitWords(str)
+	if err != nil {
+		return "", cliArgs{}, err
 	}
+	cmd, args := parseWords(words)
+	return cmd, args, nil
+}
+
+// parseArgs reads a command from arguments the shell has already split, as
+// in `pokedex explore pastoria-city-area --json`.
+func parseArgs(argv []string) (string, cliArgs) {
+	words := []word{}
+	for _, arg := range argv {
+		words = append(words, word{text: arg})
+	}
+	return parseWords(words)
+}
 
-	return result
+func parseWords(words []word) (string, cliArgs) {
+	args := cliArgs{positional: []string{}, flags: map[string]string{}}
+	if len(words) == 0 {
+		return "", args
+	}
+
+	cmd := strings.ToLower(words[0].text)
+	flagsDone := false
+	for i := 1; i < len(words); i++ {
+		w := words[i]
+		if !isFlag(w) || flagsDone {
+			args.positional = append(args.positional, w.text)
+			continue
+		}
+		if w.text == "--" {
+			flagsDone = true
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimPrefix(w.text, "--"), "=")
+		name = strings.ToLower(name)
+		if !found {
+			value = "true"
+			command, _ := lookupCommand(cmd)
+			_, takesValue := command.flagSpec(name)
+			if takesValue && i+1 < len(words) && !isFlag(words[i+1]) {
+				i++
+				value = words[i].text
+			}
+		}
+		args.flags[name] = value
+	}
+	return cmd, args
+}
+
+func isFlag(w word) bool {
+	return !w.quoted && strings.HasPrefix(w.text, "--")
+}
+
+type word struct {
+	text   string
+	quoted bool
+}
+
+func splitWords(str string) ([]word, error) {
+	words := []word{}
+	var current strings.Builder
+	inWord, quoted, escaped := false, false, false
+	var quote rune
+
+	for _, r := range str {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' {
+				escaped = true
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '\'' || r == '"':
+			quote, inWord, quoted = r, true, true
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, word{current.String(), quoted})
+				current.Reset()
+				inWord, quoted = false, false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("missing closing %c", quote)
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inWord {
+		words = append(words, word{current.String(), quoted})
+	}
+	return words, nil
+}
+
+// wrapText breaks text into lines of at most width characters, between
+// words.
+func wrapText(text string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
 }